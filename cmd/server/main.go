@@ -3,21 +3,48 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"avito-trainee-task/config"
 	"avito-trainee-task/internal/app"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+		if err := healthcheck(); err != nil {
+			log.Fatalf("Healthcheck failed: %v", err)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
 		log.Fatalf("Fatal error: %v", err)
 	}
 }
 
+// healthcheck probes readiness of the server on PORT for the container
+// healthcheck, the image has no HTTP client of its own.
+func healthcheck() error {
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://localhost:" + os.Getenv("PORT") + "/readyz")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
 func run() error {
 	cfg, err := config.LoadConfig()
 	if err != nil {
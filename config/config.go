@@ -1,6 +1,10 @@
 package config
 
-import "github.com/ilyakaznacheev/cleanenv"
+import (
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
 
 type Env string
 
@@ -12,6 +16,17 @@ const (
 type Config struct {
 	Postgres Postgres
 	Server   Server
+	GRPC     GRPC
+	SCIM     SCIM
+	Webhook  Webhook
+	GitHub   GitHub
+	GitLab   GitLab
+
+	ReviewerSync ReviewerSync
+	Chat         Chat
+	Email        Email
+	EventStream  EventStream
+	Snapshot     Snapshot
 
 	Env Env `env:"ENV" env-default:"dev"`
 }
@@ -20,8 +35,90 @@ type Postgres struct {
 	PGURL string `env:"PG_URL" env-required:"true"`
 }
 
+// Server.DrainDelay is how long /readyz reports draining before
+// the server stops accepting connections on shutdown.
 type Server struct {
-	Port string `env:"PORT" env-required:"true"`
+	Port       string        `env:"PORT" env-required:"true"`
+	DrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" env-default:"5s"`
+}
+
+// GRPC.Port is the port of the gRPC API, an empty value disables it.
+type GRPC struct {
+	Port string `env:"GRPC_PORT" env-default:"9090"`
+}
+
+type SCIM struct {
+	Token       string `env:"SCIM_TOKEN"`
+	DefaultTeam string `env:"SCIM_DEFAULT_TEAM" env-default:"unassigned"`
+}
+
+type Webhook struct {
+	DispatchInterval time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL" env-default:"5s"`
+	BatchSize        int           `env:"WEBHOOK_BATCH_SIZE" env-default:"20"`
+	MaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"10"`
+	MinBackoff       time.Duration `env:"WEBHOOK_MIN_BACKOFF" env-default:"10s"`
+	MaxBackoff       time.Duration `env:"WEBHOOK_MAX_BACKOFF" env-default:"1h"`
+	Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+
+	AllowPrivateTargets bool `env:"WEBHOOK_ALLOW_PRIVATE_TARGETS" env-default:"false"`
+}
+
+type GitHub struct {
+	WebhookSecret string `env:"GITHUB_WEBHOOK_SECRET"`
+	Token         string `env:"GITHUB_TOKEN"`
+	APIURL        string `env:"GITHUB_API_URL" env-default:"https://api.github.com"`
+}
+
+type GitLab struct {
+	WebhookToken string `env:"GITLAB_WEBHOOK_TOKEN"`
+}
+
+type ReviewerSync struct {
+	Interval    time.Duration `env:"REVIEWER_SYNC_INTERVAL" env-default:"5s"`
+	BatchSize   int           `env:"REVIEWER_SYNC_BATCH_SIZE" env-default:"20"`
+	MaxAttempts int           `env:"REVIEWER_SYNC_MAX_ATTEMPTS" env-default:"8"`
+	MinBackoff  time.Duration `env:"REVIEWER_SYNC_MIN_BACKOFF" env-default:"10s"`
+	MaxBackoff  time.Duration `env:"REVIEWER_SYNC_MAX_BACKOFF" env-default:"30m"`
+	Timeout     time.Duration `env:"REVIEWER_SYNC_TIMEOUT" env-default:"10s"`
+}
+
+type Chat struct {
+	WebhookURL    string        `env:"CHAT_WEBHOOK_URL"`
+	TemplatesFile string        `env:"CHAT_TEMPLATES_FILE"`
+	Interval      time.Duration `env:"CHAT_NOTIFY_INTERVAL" env-default:"5s"`
+	BatchSize     int           `env:"CHAT_BATCH_SIZE" env-default:"20"`
+	MaxAttempts   int           `env:"CHAT_MAX_ATTEMPTS" env-default:"5"`
+	MinBackoff    time.Duration `env:"CHAT_MIN_BACKOFF" env-default:"10s"`
+	MaxBackoff    time.Duration `env:"CHAT_MAX_BACKOFF" env-default:"10m"`
+	Timeout       time.Duration `env:"CHAT_TIMEOUT" env-default:"10s"`
+}
+
+type Email struct {
+	SMTPHost         string        `env:"SMTP_HOST"`
+	SMTPPort         int           `env:"SMTP_PORT" env-default:"587"`
+	SMTPUsername     string        `env:"SMTP_USERNAME"`
+	SMTPPassword     string        `env:"SMTP_PASSWORD"`
+	From             string        `env:"SMTP_FROM" env-default:"reviewers@localhost"`
+	TemplatesDir     string        `env:"EMAIL_TEMPLATES_DIR"`
+	Interval         time.Duration `env:"EMAIL_NOTIFY_INTERVAL" env-default:"1m"`
+	BatchSize        int           `env:"EMAIL_BATCH_SIZE" env-default:"100"`
+	MaxAttempts      int           `env:"EMAIL_MAX_ATTEMPTS" env-default:"5"`
+	MinBackoff       time.Duration `env:"EMAIL_MIN_BACKOFF" env-default:"1m"`
+	MaxBackoff       time.Duration `env:"EMAIL_MAX_BACKOFF" env-default:"1h"`
+	Timeout          time.Duration `env:"EMAIL_TIMEOUT" env-default:"30s"`
+	ReminderInterval time.Duration `env:"EMAIL_REMINDER_INTERVAL" env-default:"24h"`
+	ReminderAfter    time.Duration `env:"EMAIL_REMINDER_AFTER" env-default:"24h"`
+}
+
+type EventStream struct {
+	KeepAlive  time.Duration `env:"EVENT_STREAM_KEEPALIVE" env-default:"15s"`
+	MinBackoff time.Duration `env:"EVENT_STREAM_MIN_BACKOFF" env-default:"1s"`
+	MaxBackoff time.Duration `env:"EVENT_STREAM_MAX_BACKOFF" env-default:"30s"`
+}
+
+// Snapshot.Interval is the period of checks for the daily stats snapshot.
+type Snapshot struct {
+	Interval time.Duration `env:"STATS_SNAPSHOT_INTERVAL" env-default:"1h"`
 }
 
 func LoadConfig() (*Config, error) {
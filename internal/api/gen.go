@@ -10,10 +10,32 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	ActorIdScopes = "ActorId.Scopes"
+)
+
+// Defines values for AssignmentEventEventType.
+const (
+	Created             AssignmentEventEventType = "created"
+	DeactivatedReplaced AssignmentEventEventType = "deactivated_replaced"
+	Declined            AssignmentEventEventType = "declined"
+	Merged              AssignmentEventEventType = "merged"
+	Reassigned          AssignmentEventEventType = "reassigned"
+)
+
+// Defines values for CodeHostProvider.
+const (
+	Github CodeHostProvider = "github"
+	Gitlab CodeHostProvider = "gitlab"
 )
 
 // Defines values for ErrorResponseErrorCode.
 const (
+	BADREQUEST  ErrorResponseErrorCode = "BAD_REQUEST"
+	FORBIDDEN   ErrorResponseErrorCode = "FORBIDDEN"
 	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
 	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
 	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
@@ -22,6 +44,20 @@ const (
 	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
 )
 
+// Defines values for HealthStatus.
+const (
+	HealthFail HealthStatus = "fail"
+	HealthOk   HealthStatus = "ok"
+)
+
+// Defines values for NotificationKind.
+const (
+	KindAssigned   NotificationKind = "assigned"
+	KindMerged     NotificationKind = "merged"
+	KindReminder   NotificationKind = "reminder"
+	KindUnassigned NotificationKind = "unassigned"
+)
+
 // Defines values for PullRequestStatus.
 const (
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
@@ -34,15 +70,166 @@ const (
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for PullRequestStatStatus.
+const (
+	PullRequestStatStatusMERGED PullRequestStatStatus = "MERGED"
+	PullRequestStatStatusOPEN   PullRequestStatStatus = "OPEN"
+)
+
+// Defines values for ReviewerSyncStatus.
+const (
+	SyncFailed  ReviewerSyncStatus = "failed"
+	SyncPending ReviewerSyncStatus = "pending"
+	SyncSynced  ReviewerSyncStatus = "synced"
+)
+
+// Defines values for SortOrder.
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Defines values for TeamMemberRole.
+const (
+	Lead   TeamMemberRole = "lead"
+	Member TeamMemberRole = "member"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Dead      WebhookDeliveryStatus = "dead"
+	Delivered WebhookDeliveryStatus = "delivered"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	PrCreated    WebhookEventType = "pr.created"
+	PrMerged     WebhookEventType = "pr.merged"
+	PrReassigned WebhookEventType = "pr.reassigned"
+)
+
+// Defines values for GetPullRequestListParamsStatus.
+const (
+	GetPullRequestListParamsStatusMERGED GetPullRequestListParamsStatus = "MERGED"
+	GetPullRequestListParamsStatusOPEN   GetPullRequestListParamsStatus = "OPEN"
+)
+
+// Defines values for GetPullRequestListParamsSort.
+const (
+	CreatedAt GetPullRequestListParamsSort = "createdAt"
+	MergedAt  GetPullRequestListParamsSort = "mergedAt"
+)
+
+// Defines values for GetStatsFairnessParamsTable.
+const (
+	FairnessTableMembers GetStatsFairnessParamsTable = "members"
+	FairnessTableTeams   GetStatsFairnessParamsTable = "teams"
+)
+
+// Defines values for GetStatsTimeToMergeParamsTable.
+const (
+	TimeToMergeTableReviewers GetStatsTimeToMergeParamsTable = "reviewers"
+	TimeToMergeTableTeams     GetStatsTimeToMergeParamsTable = "teams"
+)
+
+// Defines values for GetUsersGetReviewParamsStatus.
+const (
+	GetUsersGetReviewParamsStatusALL    GetUsersGetReviewParamsStatus = "ALL"
+	GetUsersGetReviewParamsStatusMERGED GetUsersGetReviewParamsStatus = "MERGED"
+	GetUsersGetReviewParamsStatusOPEN   GetUsersGetReviewParamsStatus = "OPEN"
+)
+
+// Defines values for GetUsersStatsParamsTable.
+const (
+	UsersStatsTableAuthors      GetUsersStatsParamsTable = "authors"
+	UsersStatsTablePullRequests GetUsersStatsParamsTable = "pull_requests"
+	UsersStatsTableReviewers    GetUsersStatsParamsTable = "reviewers"
+	UsersStatsTableTeams        GetUsersStatsParamsTable = "teams"
+)
+
 // AssignmentCount defines model for AssignmentCount.
 type AssignmentCount struct {
+	// AssignmentCount Количество PR'ов, где пользователь назначен ревьювером
 	AssignmentCount int    `json:"assignment_count"`
+	MergedCount     int    `json:"merged_count"`
+	OpenCount       int    `json:"open_count"`
 	UserId          string `json:"user_id"`
 }
 
 // AssignmentCountStat defines model for AssignmentCountStat.
 type AssignmentCountStat struct {
+	Authors []AuthorStat `json:"authors"`
+
+	// From Начало окна статистики
+	From *time.Time `json:"from"`
+
+	// PullRequests PR'ы с наибольшим числом замен ревьюверов, не больше limit
+	PullRequests []PullRequestStat `json:"pull_requests"`
+
+	// Stats Статистика по ревьюверам
 	Stats []AssignmentCount `json:"stats"`
+	Teams []TeamStat        `json:"teams"`
+
+	// To Конец окна статистики
+	To *time.Time `json:"to"`
+}
+
+// AssignmentEvent defines model for AssignmentEvent.
+type AssignmentEvent struct {
+	// ActorId user_id инициатора (X-Actor-Id), отсутствует для системных изменений
+	ActorId   *string                  `json:"actor_id"`
+	CreatedAt time.Time                `json:"created_at"`
+	EventId   int64                    `json:"event_id"`
+	EventType AssignmentEventEventType `json:"event_type"`
+
+	// NewUserId Назначенный ревьювер
+	NewUserId *string `json:"new_user_id"`
+
+	// OldUserId Снятый ревьювер
+	OldUserId     *string `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+	Reason        *string `json:"reason"`
+
+	// Reviewers Ревьюверы PR после события
+	Reviewers []string `json:"reviewers"`
+}
+
+// AssignmentEventEventType defines model for AssignmentEvent.EventType.
+type AssignmentEventEventType string
+
+// AssignmentEventList defines model for AssignmentEventList.
+type AssignmentEventList struct {
+	Events []AssignmentEvent `json:"events"`
+
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor *string `json:"next_cursor"`
+}
+
+// AuthorStat defines model for AuthorStat.
+type AuthorStat struct {
+	MergedCount      int    `json:"merged_count"`
+	OpenCount        int    `json:"open_count"`
+	PullRequestCount int    `json:"pull_request_count"`
+	UserId           string `json:"user_id"`
+}
+
+// CodeHostAccount defines model for CodeHostAccount.
+type CodeHostAccount struct {
+	// Login Логин на code host, сравнивается без учёта регистра
+	Login    string           `json:"login"`
+	Provider CodeHostProvider `json:"provider"`
+	UserId   string           `json:"user_id"`
+}
+
+// CodeHostProvider defines model for CodeHostProvider.
+type CodeHostProvider string
+
+// DeactivationResult defines model for DeactivationResult.
+type DeactivationResult struct {
+	DeactivatedUserIds []string       `json:"deactivated_user_ids"`
+	Reassignments      []Reassignment `json:"reassignments"`
+	TeamName           string         `json:"team_name"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -56,6 +243,76 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// FairnessStat defines model for FairnessStat.
+type FairnessStat struct {
+	From  *time.Time     `json:"from"`
+	Teams []TeamFairness `json:"teams"`
+	To    *time.Time     `json:"to"`
+}
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	Message *string      `json:"message,omitempty"`
+	Status  HealthStatus `json:"status"`
+}
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
+
+// ImportMove defines model for ImportMove.
+type ImportMove struct {
+	FromTeam string `json:"from_team"`
+	ToTeam   string `json:"to_team"`
+	UserId   string `json:"user_id"`
+}
+
+// ImportReport defines model for ImportReport.
+type ImportReport struct {
+	// Created user_id созданных пользователей
+	Created []string `json:"created"`
+
+	// Deactivated user_id деактивированных пользователей, в том числе отсутствующих в файле
+	Deactivated []string     `json:"deactivated"`
+	DryRun      bool         `json:"dry_run"`
+	Moved       []ImportMove `json:"moved"`
+
+	// Reassignments Переназначения ревьюверов открытых PR (только при применении)
+	Reassignments []Reassignment `json:"reassignments"`
+
+	// Updated user_id пользователей с изменённым именем, ролью или повторно активированных
+	Updated []string `json:"updated"`
+}
+
+// Liveness defines model for Liveness.
+type Liveness struct {
+	Status HealthStatus `json:"status"`
+}
+
+// MemberLoad defines model for MemberLoad.
+type MemberLoad struct {
+	AssignmentCount int    `json:"assignment_count"`
+	UserId          string `json:"user_id"`
+}
+
+// NotificationContact defines model for NotificationContact.
+type NotificationContact struct {
+	// ChatChannel Канал чата для личных уведомлений, без него используется канал входящего вебхука
+	ChatChannel *string `json:"chat_channel"`
+
+	// ChatHandle Имя пользователя в чате для упоминания
+	ChatHandle *string `json:"chat_handle"`
+
+	// Email Адрес для уведомлений по почте
+	Email *openapi_types.Email `json:"email"`
+
+	// EmailOptOut Виды писем, от которых пользователь отказался
+	EmailOptOut *[]NotificationKind `json:"email_opt_out,omitempty"`
+	UserId      string              `json:"user_id"`
+}
+
+// NotificationKind assigned - назначение ревьювером, unassigned - замена ревьювера другим пользователем, merged - merge PR, reminder - напоминание об открытых PR на ревью
+type NotificationKind string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id назначенных ревьюверов (0..2)
@@ -71,6 +328,22 @@ type PullRequest struct {
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestDetails defines model for PullRequestDetails.
+type PullRequestDetails struct {
+	History AssignmentEventList `json:"history"`
+	Pr      PullRequest         `json:"pr"`
+
+	// Sync Состояние передачи назначенных ревьюверов в PR на code host
+	Sync *ReviewerSync `json:"sync,omitempty"`
+}
+
+// PullRequestList defines model for PullRequestList.
+type PullRequestList struct {
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor   *string       `json:"next_cursor"`
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
 	AuthorId        string                 `json:"author_id"`
@@ -82,17 +355,165 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// PullRequestStat defines model for PullRequestStat.
+type PullRequestStat struct {
+	AuthorId      string `json:"author_id"`
+	PullRequestId string `json:"pull_request_id"`
+
+	// ReassignmentCount Замены ревьюверов (переназначения, отказы и деактивации)
+	ReassignmentCount int `json:"reassignment_count"`
+
+	// ReviewerCount Количество назначенных сейчас ревьюверов
+	ReviewerCount int                   `json:"reviewer_count"`
+	Status        PullRequestStatStatus `json:"status"`
+}
+
+// PullRequestStatStatus defines model for PullRequestStat.Status.
+type PullRequestStatStatus string
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	// Checks Результаты проверок database, migrations и draining
+	Checks map[string]HealthCheck `json:"checks"`
+	Status HealthStatus           `json:"status"`
+}
+
+// Reassignment defines model for Reassignment.
+type Reassignment struct {
+	OldUserId     string `json:"old_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+
+	// ReplacedBy user_id нового ревьювера, отсутствует если кандидатов нет
+	ReplacedBy *string `json:"replaced_by"`
+}
+
+// ReviewList defines model for ReviewList.
+type ReviewList struct {
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor   *string            `json:"next_cursor"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+
+	// Total Количество PR'ов, подходящих под фильтр, без учёта постраничной выдачи
+	Total  int    `json:"total"`
+	UserId string `json:"user_id"`
+}
+
+// ReviewerMergeTime Время от создания до merge PR'ов, где пользователь назначен ревьювером, за неделю
+type ReviewerMergeTime struct {
+	MedianSeconds int    `json:"median_seconds"`
+	MergedCount   int    `json:"merged_count"`
+	P90Seconds    int    `json:"p90_seconds"`
+	UserId        string `json:"user_id"`
+
+	// Week Начало недели (понедельник 00:00 UTC), в которую PR'ы смержены
+	Week time.Time `json:"week"`
+}
+
+// ReviewerSync Состояние передачи назначенных ревьюверов в PR на code host
+type ReviewerSync struct {
+	// Attempts Число неудачных попыток с момента последнего изменения ревьюверов
+	Attempts   int                `json:"attempts"`
+	LastError  *string            `json:"last_error"`
+	Number     int                `json:"number"`
+	Provider   CodeHostProvider   `json:"provider"`
+	Repository string             `json:"repository"`
+	Status     ReviewerSyncStatus `json:"status"`
+	SyncedAt   *time.Time         `json:"synced_at"`
+}
+
+// ReviewerSyncStatus defines model for ReviewerSyncStatus.
+type ReviewerSyncStatus string
+
+// SortOrder defines model for SortOrder.
+type SortOrder string
+
 // Team defines model for Team.
 type Team struct {
 	Members  []TeamMember `json:"members"`
 	TeamName string       `json:"team_name"`
 }
 
+// TeamFairness Распределение назначений ревью между активными участниками команды
+type TeamFairness struct {
+	AssignmentCount int `json:"assignment_count"`
+
+	// Gini Коэффициент Джини, 0 - нагрузка распределена поровну
+	Gini        float32      `json:"gini"`
+	Max         int          `json:"max"`
+	Mean        float32      `json:"mean"`
+	MemberCount int          `json:"member_count"`
+	Members     []MemberLoad `json:"members"`
+	Min         int          `json:"min"`
+
+	// OverMean Участники с нагрузкой выше средней по команде
+	OverMean []string `json:"over_mean"`
+
+	// Stddev Стандартное отклонение по всем активным участникам
+	Stddev   float32 `json:"stddev"`
+	TeamName string  `json:"team_name"`
+
+	// UnderMean Участники с нагрузкой ниже средней по команде
+	UnderMean []string `json:"under_mean"`
+}
+
+// TeamList defines model for TeamList.
+type TeamList struct {
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor *string       `json:"next_cursor"`
+	Teams      []TeamSummary `json:"teams"`
+}
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// Role Роль участника в команде
+	Role     *TeamMemberRole `json:"role,omitempty"`
+	UserId   string          `json:"user_id"`
+	Username string          `json:"username"`
+}
+
+// TeamMemberRole Роль участника в команде
+type TeamMemberRole string
+
+// TeamMergeTime Время от создания до merge PR'ов, автор которых состоит в команде, за неделю
+type TeamMergeTime struct {
+	MedianSeconds int    `json:"median_seconds"`
+	MergedCount   int    `json:"merged_count"`
+	P90Seconds    int    `json:"p90_seconds"`
+	TeamName      string `json:"team_name"`
+
+	// Week Начало недели (понедельник 00:00 UTC), в которую PR'ы смержены
+	Week time.Time `json:"week"`
+}
+
+// TeamStat Статистика PR'ов, автор которых состоит в команде
+type TeamStat struct {
+	MergedCount      int `json:"merged_count"`
+	OpenCount        int `json:"open_count"`
+	PullRequestCount int `json:"pull_request_count"`
+
+	// ReassignmentCount Замены ревьюверов в PR'ах команды (переназначения, отказы и деактивации)
+	ReassignmentCount int    `json:"reassignment_count"`
+	TeamName          string `json:"team_name"`
+}
+
+// TeamSummary defines model for TeamSummary.
+type TeamSummary struct {
+	ActiveMemberCount int `json:"active_member_count"`
+	MemberCount       int `json:"member_count"`
+
+	// OpenPullRequestCount Количество открытых PR'ов, автор которых состоит в команде
+	OpenPullRequestCount int    `json:"open_pull_request_count"`
+	TeamName             string `json:"team_name"`
+}
+
+// TimeToMergeStat defines model for TimeToMergeStat.
+type TimeToMergeStat struct {
+	From      *time.Time          `json:"from"`
+	Reviewers []ReviewerMergeTime `json:"reviewers"`
+	Teams     []TeamMergeTime     `json:"teams"`
+	To        *time.Time          `json:"to"`
 }
 
 // User defines model for User.
@@ -103,12 +524,120 @@ type User struct {
 	Username string `json:"username"`
 }
 
+// UserDetails defines model for UserDetails.
+type UserDetails struct {
+	IsActive bool `json:"is_active"`
+
+	// OpenReviewCount Количество открытых PR'ов, где пользователь назначен ревьювером
+	OpenReviewCount int    `json:"open_review_count"`
+	TeamName        string `json:"team_name"`
+	UserId          string `json:"user_id"`
+	Username        string `json:"username"`
+}
+
+// UserList defines model for UserList.
+type UserList struct {
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor *string       `json:"next_cursor"`
+	Users      []UserDetails `json:"users"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+	DeliveryId  int64      `json:"delivery_id"`
+	EventId     int64      `json:"event_id"`
+
+	// EventType pr.created - PR создан; pr.reassigned - ревьювер заменён (переназначение, отказ или деактивация); pr.merged - PR смержен
+	EventType      WebhookEventType      `json:"event_type"`
+	LastError      *string               `json:"last_error"`
+	LastStatusCode *int                  `json:"last_status_code"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	SubscriptionId int64                 `json:"subscription_id"`
+}
+
+// WebhookDeliveryList defines model for WebhookDeliveryList.
+type WebhookDeliveryList struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor *string `json:"next_cursor"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookEventType pr.created - PR создан; pr.reassigned - ревьювер заменён (переназначение, отказ или деактивация); pr.merged - PR смержен
+type WebhookEventType string
+
+// WebhookPayload Тело запроса, отправляемого подписчику. Подпись HMAC-SHA256 тела секретом подписки передаётся в заголовке X-Webhook-Signature-256 в виде sha256=<hex>
+type WebhookPayload struct {
+	Data    AssignmentEvent `json:"data"`
+	EventId int64           `json:"event_id"`
+
+	// EventType pr.created - PR создан; pr.reassigned - ревьювер заменён (переназначение, отказ или деактивация); pr.merged - PR смержен
+	EventType  WebhookEventType `json:"event_type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventTypes Типы событий подписки, пустой список - все события
+	EventTypes     []WebhookEventType `json:"event_types"`
+	SubscriptionId int64              `json:"subscription_id"`
+	Url            string             `json:"url"`
+}
+
+// CursorQuery defines model for CursorQuery.
+type CursorQuery = string
+
+// LimitQuery defines model for LimitQuery.
+type LimitQuery = int
+
+// OrderQuery defines model for OrderQuery.
+type OrderQuery = SortOrder
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// PostImportParams defines parameters for PostImport.
+type PostImportParams struct {
+	// DryRun Только сформировать отчёт без применения изменений
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// DeactivateMissing Деактивировать пользователей, отсутствующих в файле
+	DeactivateMissing *bool `form:"deactivate_missing,omitempty" json:"deactivate_missing,omitempty"`
+}
+
+// PostIntegrationsAccountsDeleteJSONBody defines parameters for PostIntegrationsAccountsDelete.
+type PostIntegrationsAccountsDeleteJSONBody struct {
+	Login    string           `json:"login"`
+	Provider CodeHostProvider `json:"provider"`
+}
+
+// GetIntegrationsAccountsListParams defines parameters for GetIntegrationsAccountsList.
+type GetIntegrationsAccountsListParams struct {
+	// Provider Фильтр по провайдеру
+	Provider *CodeHostProvider `form:"provider,omitempty" json:"provider,omitempty"`
+}
+
+// PostIntegrationsSyncRetryJSONBody defines parameters for PostIntegrationsSyncRetry.
+type PostIntegrationsSyncRetryJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostNotificationsContactsDeleteJSONBody defines parameters for PostNotificationsContactsDelete.
+type PostNotificationsContactsDeleteJSONBody struct {
+	UserId string `json:"user_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
 	AuthorId        string `json:"author_id"`
@@ -116,6 +645,72 @@ type PostPullRequestCreateJSONBody struct {
 	PullRequestName string `json:"pull_request_name"`
 }
 
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+
+	// Order Направление сортировки
+	Order *OrderQuery `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	// Status Фильтр по статусу
+	Status *GetPullRequestListParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// AuthorId Фильтр по автору
+	AuthorId *string `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// ReviewerId Фильтр по назначенному ревьюверу
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Фильтр по команде автора
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// CreatedFrom PR созданы не раньше указанного момента
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo PR созданы раньше указанного момента
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom PR смержены не раньше указанного момента
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo PR смержены раньше указанного момента
+	MergedTo *time.Time `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Sort Поле сортировки, PR без mergedAt идут последними
+	Sort *GetPullRequestListParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Направление сортировки
+	Order *OrderQuery `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestListParamsStatus defines parameters for GetPullRequestList.
+type GetPullRequestListParamsStatus string
+
+// GetPullRequestListParamsSort defines parameters for GetPullRequestList.
+type GetPullRequestListParamsSort string
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
@@ -125,6 +720,51 @@ type PostPullRequestMergeJSONBody struct {
 type PostPullRequestReassignJSONBody struct {
 	OldUserId     string `json:"old_user_id"`
 	PullRequestId string `json:"pull_request_id"`
+
+	// Reason Причина переназначения, сохраняется в истории PR
+	Reason *string `json:"reason,omitempty"`
+}
+
+// GetStatsFairnessParams defines parameters for GetStatsFairness.
+type GetStatsFairnessParams struct {
+	// From PR созданы не раньше указанного момента
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To PR созданы раньше указанного момента
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// TeamName Только указанная команда
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Table Таблица, выгружаемая при `Accept: text/csv`: статистика команд или нагрузка участников
+	Table *GetStatsFairnessParamsTable `form:"table,omitempty" json:"table,omitempty"`
+}
+
+// GetStatsFairnessParamsTable defines parameters for GetStatsFairness.
+type GetStatsFairnessParamsTable string
+
+// GetStatsTimeToMergeParams defines parameters for GetStatsTimeToMerge.
+type GetStatsTimeToMergeParams struct {
+	// From PR смержены не раньше указанного момента
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To PR смержены раньше указанного момента
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// TeamName Только PR'ы авторов команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Table Таблица, выгружаемая при `Accept: text/csv`
+	Table *GetStatsTimeToMergeParamsTable `form:"table,omitempty" json:"table,omitempty"`
+}
+
+// GetStatsTimeToMergeParamsTable defines parameters for GetStatsTimeToMerge.
+type GetStatsTimeToMergeParamsTable string
+
+// PostTeamDeactivateMembersJSONBody defines parameters for PostTeamDeactivateMembers.
+type PostTeamDeactivateMembersJSONBody struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids"`
 }
 
 // GetTeamGetParams defines parameters for GetTeamGet.
@@ -133,10 +773,73 @@ type GetTeamGetParams struct {
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// GetTeamListParams defines parameters for GetTeamList.
+type GetTeamListParams struct {
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersGetParams defines parameters for GetUsersGet.
+type GetUsersGetParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// Status Фильтр по статусу, ALL возвращает PR'ы в любом статусе
+	Status *GetUsersGetReviewParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Order Направление сортировки
+	Order *OrderQuery `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersGetReviewParamsStatus defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsStatus string
+
+// GetUsersHistoryParams defines parameters for GetUsersHistory.
+type GetUsersHistoryParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// Order Направление сортировки
+	Order *OrderQuery `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersListParams defines parameters for GetUsersList.
+type GetUsersListParams struct {
+	// TeamName Фильтр по команде
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// IsActive Фильтр по флагу активности
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// UsernamePrefix Фильтр по префиксу имени пользователя
+	UsernamePrefix *string `form:"username_prefix,omitempty" json:"username_prefix,omitempty"`
+
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
@@ -145,47 +848,203 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
-// PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
-type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+// GetUsersStatsParams defines parameters for GetUsersStats.
+type GetUsersStatsParams struct {
+	// From Начало окна, включительно
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
 
-// PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
-type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
+	// To Конец окна, не включительно
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
 
-// PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
-type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
+	// Table Таблица, выгружаемая при `Accept: text/csv`
+	Table *GetUsersStatsParamsTable `form:"table,omitempty" json:"table,omitempty"`
 
-// PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
-type PostTeamAddJSONRequestBody = Team
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+}
 
-// PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
-type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+// GetUsersStatsParamsTable defines parameters for GetUsersStats.
+type GetUsersStatsParamsTable string
+
+// GetWebhooksDeliveriesParams defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParams struct {
+	// Status Фильтр по статусу доставки
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// SubscriptionId Фильтр по подписке
+	SubscriptionId *int64 `form:"subscription_id,omitempty" json:"subscription_id,omitempty"`
+
+	// Limit Максимальное количество элементов на странице
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (значение next_cursor из предыдущего ответа)
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PostWebhooksRedeliverJSONBody defines parameters for PostWebhooksRedeliver.
+type PostWebhooksRedeliverJSONBody struct {
+	DeliveryId int64 `json:"delivery_id"`
+}
+
+// PostWebhooksSubscribeJSONBody defines parameters for PostWebhooksSubscribe.
+type PostWebhooksSubscribeJSONBody struct {
+	EventTypes *[]WebhookEventType `json:"event_types,omitempty"`
+
+	// Secret Секрет для подписи тела запроса
+	Secret string `json:"secret"`
+
+	// Url Абсолютный http(s) URL подписчика
+	Url string `json:"url"`
+}
+
+// PostWebhooksUnsubscribeJSONBody defines parameters for PostWebhooksUnsubscribe.
+type PostWebhooksUnsubscribeJSONBody struct {
+	SubscriptionId int64 `json:"subscription_id"`
+}
+
+// PostIntegrationsAccountsDeleteJSONRequestBody defines body for PostIntegrationsAccountsDelete for application/json ContentType.
+type PostIntegrationsAccountsDeleteJSONRequestBody PostIntegrationsAccountsDeleteJSONBody
+
+// PostIntegrationsAccountsSetJSONRequestBody defines body for PostIntegrationsAccountsSet for application/json ContentType.
+type PostIntegrationsAccountsSetJSONRequestBody = CodeHostAccount
+
+// PostIntegrationsSyncRetryJSONRequestBody defines body for PostIntegrationsSyncRetry for application/json ContentType.
+type PostIntegrationsSyncRetryJSONRequestBody PostIntegrationsSyncRetryJSONBody
+
+// PostNotificationsContactsDeleteJSONRequestBody defines body for PostNotificationsContactsDelete for application/json ContentType.
+type PostNotificationsContactsDeleteJSONRequestBody PostNotificationsContactsDeleteJSONBody
+
+// PostNotificationsContactsSetJSONRequestBody defines body for PostNotificationsContactsSet for application/json ContentType.
+type PostNotificationsContactsSetJSONRequestBody = NotificationContact
+
+// PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
+type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+
+// PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
+type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
+
+// PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
+type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
+
+// PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
+type PostTeamAddJSONRequestBody = Team
+
+// PostTeamDeactivateMembersJSONRequestBody defines body for PostTeamDeactivateMembers for application/json ContentType.
+type PostTeamDeactivateMembersJSONRequestBody PostTeamDeactivateMembersJSONBody
+
+// PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
+type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostWebhooksRedeliverJSONRequestBody defines body for PostWebhooksRedeliver for application/json ContentType.
+type PostWebhooksRedeliverJSONRequestBody PostWebhooksRedeliverJSONBody
+
+// PostWebhooksSubscribeJSONRequestBody defines body for PostWebhooksSubscribe for application/json ContentType.
+type PostWebhooksSubscribeJSONRequestBody PostWebhooksSubscribeJSONBody
+
+// PostWebhooksUnsubscribeJSONRequestBody defines body for PostWebhooksUnsubscribe for application/json ContentType.
+type PostWebhooksUnsubscribeJSONRequestBody PostWebhooksUnsubscribeJSONBody
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Liveness - процесс запущен и обрабатывает запросы
+	// (GET /healthz)
+	GetHealthz(ctx echo.Context) error
+	// Импортировать оргструктуру (команды и пользователей) из CSV или YAML
+	// (POST /import)
+	PostImport(ctx echo.Context, params PostImportParams) error
+	// Удалить сопоставление логина
+	// (POST /integrations/accounts/delete)
+	PostIntegrationsAccountsDelete(ctx echo.Context) error
+	// Получить сопоставления логинов
+	// (GET /integrations/accounts/list)
+	GetIntegrationsAccountsList(ctx echo.Context, params GetIntegrationsAccountsListParams) error
+	// Сопоставить логин на code host пользователю сервиса
+	// (POST /integrations/accounts/set)
+	PostIntegrationsAccountsSet(ctx echo.Context) error
+	// Повторить передачу ревьюверов PR на code host
+	// (POST /integrations/sync/retry)
+	PostIntegrationsSyncRetry(ctx echo.Context) error
+	// Удалить контакты пользователя
+	// (POST /notifications/contacts/delete)
+	PostNotificationsContactsDelete(ctx echo.Context) error
+	// Получить контакты пользователей
+	// (GET /notifications/contacts/list)
+	GetNotificationsContactsList(ctx echo.Context) error
+	// Задать контакты пользователя для уведомлений
+	// (POST /notifications/contacts/set)
+	PostNotificationsContactsSet(ctx echo.Context) error
 	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(ctx echo.Context) error
+	// Получить PR по идентификатору
+	// (GET /pullRequest/get)
+	GetPullRequestGet(ctx echo.Context, params GetPullRequestGetParams) error
+	// Получить историю назначений ревьюверов PR
+	// (GET /pullRequest/history)
+	GetPullRequestHistory(ctx echo.Context, params GetPullRequestHistoryParams) error
+	// Получить список PR с фильтрацией, сортировкой и постраничной выдачей
+	// (GET /pullRequest/list)
+	GetPullRequestList(ctx echo.Context, params GetPullRequestListParams) error
 	// Пометить PR как MERGED (идемпотентная операция)
 	// (POST /pullRequest/merge)
 	PostPullRequestMerge(ctx echo.Context) error
 	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
 	PostPullRequestReassign(ctx echo.Context) error
+	// Readiness - сервис готов принимать трафик
+	// (GET /readyz)
+	GetReadyz(ctx echo.Context) error
+	// Распределение нагрузки ревью внутри команд
+	// (GET /stats/fairness)
+	GetStatsFairness(ctx echo.Context, params GetStatsFairnessParams) error
+	// Медиана и 90-й перцентиль времени до merge по неделям для команд и ревьюверов
+	// (GET /stats/timeToMerge)
+	GetStatsTimeToMerge(ctx echo.Context, params GetStatsTimeToMergeParams) error
 	// Создать команду с участниками (создаёт/обновляет пользователей)
 	// (POST /team/add)
 	PostTeamAdd(ctx echo.Context) error
+	// Массово деактивировать участников команды и переназначить их открытые PR
+	// (POST /team/deactivateMembers)
+	PostTeamDeactivateMembers(ctx echo.Context) error
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(ctx echo.Context, params GetTeamGetParams) error
+	// Получить список команд с количеством участников и открытых PR'ов
+	// (GET /team/list)
+	GetTeamList(ctx echo.Context, params GetTeamListParams) error
+	// Получить пользователя с количеством открытых ревью
+	// (GET /users/get)
+	GetUsersGet(ctx echo.Context, params GetUsersGetParams) error
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error
+	// Получить историю назначений, затрагивающих пользователя
+	// (GET /users/history)
+	GetUsersHistory(ctx echo.Context, params GetUsersHistoryParams) error
+	// Получить список пользователей с фильтрацией и постраничной выдачей
+	// (GET /users/list)
+	GetUsersList(ctx echo.Context, params GetUsersListParams) error
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(ctx echo.Context) error
-	// Получить количество назначенных PR'ов для каждого пользователя
+	// Получить статистику назначений по ревьюверам, командам, авторам и PR'ам
 	// (GET /users/stats)
-	GetUsersStats(ctx echo.Context) error
+	GetUsersStats(ctx echo.Context, params GetUsersStatsParams) error
+	// Получить список доставок событий
+	// (GET /webhooks/deliveries)
+	GetWebhooksDeliveries(ctx echo.Context, params GetWebhooksDeliveriesParams) error
+	// Получить список подписок
+	// (GET /webhooks/list)
+	GetWebhooksList(ctx echo.Context) error
+	// Повторно отправить событие
+	// (POST /webhooks/redeliver)
+	PostWebhooksRedeliver(ctx echo.Context) error
+	// Подписать HTTP-эндпоинт на события
+	// (POST /webhooks/subscribe)
+	PostWebhooksSubscribe(ctx echo.Context) error
+	// Удалить подписку вместе с её недоставленными событиями
+	// (POST /webhooks/unsubscribe)
+	PostWebhooksUnsubscribe(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -193,6 +1052,118 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetHealthz converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthz(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthz(ctx)
+	return err
+}
+
+// PostImport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostImport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostImportParams
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", ctx.QueryParams(), &params.DryRun)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dry_run: %s", err))
+	}
+
+	// ------------- Optional query parameter "deactivate_missing" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deactivate_missing", ctx.QueryParams(), &params.DeactivateMissing)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deactivate_missing: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostImport(ctx, params)
+	return err
+}
+
+// PostIntegrationsAccountsDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIntegrationsAccountsDelete(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostIntegrationsAccountsDelete(ctx)
+	return err
+}
+
+// GetIntegrationsAccountsList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIntegrationsAccountsList(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIntegrationsAccountsListParams
+	// ------------- Optional query parameter "provider" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "provider", ctx.QueryParams(), &params.Provider)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter provider: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetIntegrationsAccountsList(ctx, params)
+	return err
+}
+
+// PostIntegrationsAccountsSet converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIntegrationsAccountsSet(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostIntegrationsAccountsSet(ctx)
+	return err
+}
+
+// PostIntegrationsSyncRetry converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIntegrationsSyncRetry(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostIntegrationsSyncRetry(ctx)
+	return err
+}
+
+// PostNotificationsContactsDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNotificationsContactsDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNotificationsContactsDelete(ctx)
+	return err
+}
+
+// GetNotificationsContactsList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNotificationsContactsList(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNotificationsContactsList(ctx)
+	return err
+}
+
+// PostNotificationsContactsSet converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNotificationsContactsSet(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNotificationsContactsSet(ctx)
+	return err
+}
+
 // PostPullRequestCreate converts echo context to params.
 func (w *ServerInterfaceWrapper) PostPullRequestCreate(ctx echo.Context) error {
 	var err error
@@ -202,10 +1173,164 @@ func (w *ServerInterfaceWrapper) PostPullRequestCreate(ctx echo.Context) error {
 	return err
 }
 
+// GetPullRequestGet converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPullRequestGet(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestGetParams
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", ctx.QueryParams(), &params.PullRequestId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pull_request_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPullRequestGet(ctx, params)
+	return err
+}
+
+// GetPullRequestHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPullRequestHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestHistoryParams
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", ctx.QueryParams(), &params.PullRequestId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pull_request_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPullRequestHistory(ctx, params)
+	return err
+}
+
+// GetPullRequestList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPullRequestList(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestListParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", ctx.QueryParams(), &params.AuthorId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", ctx.QueryParams(), &params.ReviewerId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reviewer_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_to: %s", err))
+	}
+
+	// ------------- Optional query parameter "merged_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_from", ctx.QueryParams(), &params.MergedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter merged_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "merged_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_to", ctx.QueryParams(), &params.MergedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter merged_to: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPullRequestList(ctx, params)
+	return err
+}
+
 // PostPullRequestMerge converts echo context to params.
 func (w *ServerInterfaceWrapper) PostPullRequestMerge(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ActorIdScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostPullRequestMerge(ctx)
 	return err
@@ -215,20 +1340,122 @@ func (w *ServerInterfaceWrapper) PostPullRequestMerge(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostPullRequestReassign(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ActorIdScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostPullRequestReassign(ctx)
 	return err
 }
 
+// GetReadyz converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReadyz(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetReadyz(ctx)
+	return err
+}
+
+// GetStatsFairness converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatsFairness(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsFairnessParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", ctx.QueryParams(), &params.Table)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter table: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStatsFairness(ctx, params)
+	return err
+}
+
+// GetStatsTimeToMerge converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatsTimeToMerge(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsTimeToMergeParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", ctx.QueryParams(), &params.Table)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter table: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStatsTimeToMerge(ctx, params)
+	return err
+}
+
 // PostTeamAdd converts echo context to params.
 func (w *ServerInterfaceWrapper) PostTeamAdd(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ActorIdScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostTeamAdd(ctx)
 	return err
 }
 
+// PostTeamDeactivateMembers converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamDeactivateMembers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamDeactivateMembers(ctx)
+	return err
+}
+
 // GetTeamGet converts echo context to params.
 func (w *ServerInterfaceWrapper) GetTeamGet(ctx echo.Context) error {
 	var err error
@@ -247,6 +1474,49 @@ func (w *ServerInterfaceWrapper) GetTeamGet(ctx echo.Context) error {
 	return err
 }
 
+// GetTeamList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTeamList(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamListParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTeamList(ctx, params)
+	return err
+}
+
+// GetUsersGet converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersGet(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersGetParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersGet(ctx, params)
+	return err
+}
+
 // GetUsersGetReview converts echo context to params.
 func (w *ServerInterfaceWrapper) GetUsersGetReview(ctx echo.Context) error {
 	var err error
@@ -260,11 +1530,124 @@ func (w *ServerInterfaceWrapper) GetUsersGetReview(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetUsersGetReview(ctx, params)
 	return err
 }
 
+// GetUsersHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersHistoryParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersHistory(ctx, params)
+	return err
+}
+
+// GetUsersList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersList(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersListParams
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "is_active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_active", ctx.QueryParams(), &params.IsActive)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter is_active: %s", err))
+	}
+
+	// ------------- Optional query parameter "username_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username_prefix", ctx.QueryParams(), &params.UsernamePrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username_prefix: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersList(ctx, params)
+	return err
+}
+
 // PostUsersSetIsActive converts echo context to params.
 func (w *ServerInterfaceWrapper) PostUsersSetIsActive(ctx echo.Context) error {
 	var err error
@@ -278,8 +1661,119 @@ func (w *ServerInterfaceWrapper) PostUsersSetIsActive(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) GetUsersStats(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersStatsParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", ctx.QueryParams(), &params.Table)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter table: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersStats(ctx, params)
+	return err
+}
+
+// GetWebhooksDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksDeliveries(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksDeliveriesParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "subscription_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subscription_id", ctx.QueryParams(), &params.SubscriptionId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subscription_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksDeliveries(ctx, params)
+	return err
+}
+
+// GetWebhooksList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksList(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksList(ctx)
+	return err
+}
+
+// PostWebhooksRedeliver converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksRedeliver(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksRedeliver(ctx)
+	return err
+}
+
+// PostWebhooksSubscribe converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksSubscribe(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksSubscribe(ctx)
+	return err
+}
+
+// PostWebhooksUnsubscribe converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksUnsubscribe(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ActorIdScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsersStats(ctx)
+	err = w.Handler.PostWebhooksUnsubscribe(ctx)
 	return err
 }
 
@@ -311,13 +1805,38 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/healthz", wrapper.GetHealthz)
+	router.POST(baseURL+"/import", wrapper.PostImport)
+	router.POST(baseURL+"/integrations/accounts/delete", wrapper.PostIntegrationsAccountsDelete)
+	router.GET(baseURL+"/integrations/accounts/list", wrapper.GetIntegrationsAccountsList)
+	router.POST(baseURL+"/integrations/accounts/set", wrapper.PostIntegrationsAccountsSet)
+	router.POST(baseURL+"/integrations/sync/retry", wrapper.PostIntegrationsSyncRetry)
+	router.POST(baseURL+"/notifications/contacts/delete", wrapper.PostNotificationsContactsDelete)
+	router.GET(baseURL+"/notifications/contacts/list", wrapper.GetNotificationsContactsList)
+	router.POST(baseURL+"/notifications/contacts/set", wrapper.PostNotificationsContactsSet)
 	router.POST(baseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
+	router.GET(baseURL+"/pullRequest/get", wrapper.GetPullRequestGet)
+	router.GET(baseURL+"/pullRequest/history", wrapper.GetPullRequestHistory)
+	router.GET(baseURL+"/pullRequest/list", wrapper.GetPullRequestList)
 	router.POST(baseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
 	router.POST(baseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
+	router.GET(baseURL+"/readyz", wrapper.GetReadyz)
+	router.GET(baseURL+"/stats/fairness", wrapper.GetStatsFairness)
+	router.GET(baseURL+"/stats/timeToMerge", wrapper.GetStatsTimeToMerge)
 	router.POST(baseURL+"/team/add", wrapper.PostTeamAdd)
+	router.POST(baseURL+"/team/deactivateMembers", wrapper.PostTeamDeactivateMembers)
 	router.GET(baseURL+"/team/get", wrapper.GetTeamGet)
+	router.GET(baseURL+"/team/list", wrapper.GetTeamList)
+	router.GET(baseURL+"/users/get", wrapper.GetUsersGet)
 	router.GET(baseURL+"/users/getReview", wrapper.GetUsersGetReview)
+	router.GET(baseURL+"/users/history", wrapper.GetUsersHistory)
+	router.GET(baseURL+"/users/list", wrapper.GetUsersList)
 	router.POST(baseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
 	router.GET(baseURL+"/users/stats", wrapper.GetUsersStats)
+	router.GET(baseURL+"/webhooks/deliveries", wrapper.GetWebhooksDeliveries)
+	router.GET(baseURL+"/webhooks/list", wrapper.GetWebhooksList)
+	router.POST(baseURL+"/webhooks/redeliver", wrapper.PostWebhooksRedeliver)
+	router.POST(baseURL+"/webhooks/subscribe", wrapper.PostWebhooksSubscribe)
+	router.POST(baseURL+"/webhooks/unsubscribe", wrapper.PostWebhooksUnsubscribe)
 
 }
@@ -3,34 +3,189 @@ package app
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
 	"avito-trainee-task/config"
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/codehost"
+	"avito-trainee-task/internal/controller/http/integrations"
+	"avito-trainee-task/internal/controller/http/scim"
+	"avito-trainee-task/internal/controller/http/stream"
 	v1 "avito-trainee-task/internal/controller/http/v1"
+	"avito-trainee-task/internal/controller/rpc"
+	"avito-trainee-task/internal/events"
+	"avito-trainee-task/internal/metrics"
+	"avito-trainee-task/internal/notify"
+	"avito-trainee-task/internal/snapshot"
 	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/webhook"
+	"avito-trainee-task/internal/worker"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func Run(ctx context.Context, cfg *config.Config) error {
 	logger := setupLogger(cfg)
 	slog.SetDefault(logger)
 
+	templates, err := notify.LoadTemplates(cfg.Chat.TemplatesFile)
+	if err != nil {
+		return err
+	}
+	emailTemplates, err := notify.LoadEmailTemplates(cfg.Email.TemplatesDir)
+	if err != nil {
+		return err
+	}
+
 	pool, err := postgres.NewPool(ctx, cfg.Postgres.PGURL)
 	if err != nil {
 		panic(err)
 	}
 	s := postgres.NewWithPool(pool)
+
+	var transports []postgres.NotificationTransport
+	if cfg.Chat.WebhookURL != "" {
+		transports = append(transports, postgres.ChatTransport)
+	}
+	if cfg.Email.SMTPHost != "" {
+		transports = append(transports, postgres.EmailTransport)
+	}
+	s.SetNotificationTransports(transports...)
 	defer s.Close()
 
 	e := echo.New()
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: "${method} ${uri} ${status} ${error}\n",
 	}))
+	metrics.Registry.MustRegister(metrics.NewPoolCollector(pool), metrics.NewPullRequestCollector(s))
+	metrics.RegisterRoutes(e)
 	h := v1.NewHandler(s)
 	h.RegisterRoutes(e)
+	if cfg.SCIM.Token != "" {
+		scim.NewHandler(s, cfg.SCIM.Token, cfg.SCIM.DefaultTeam).RegisterRoutes(e)
+	}
+	if cfg.GitHub.WebhookSecret != "" {
+		integrations.NewGitHubHandler(s, cfg.GitHub.WebhookSecret).RegisterRoutes(e)
+	}
+	if cfg.GitLab.WebhookToken != "" {
+		integrations.NewGitLabHandler(s, cfg.GitLab.WebhookToken).RegisterRoutes(e)
+	}
+
+	// Streams end when the broker stops, otherwise they would hold
+	// the graceful shutdown until its timeout.
+	broker := events.NewBroker(s, events.Config{
+		MinBackoff: cfg.EventStream.MinBackoff,
+		MaxBackoff: cfg.EventStream.MaxBackoff,
+	})
+	go broker.Run(ctx)
+	stream.NewHandler(s, broker, cfg.EventStream.KeepAlive).RegisterRoutes(e)
+
+	grpcServer := grpc.NewServer()
+	rpc.NewServer(s, broker, cfg.EventStream.KeepAlive).Register(grpcServer)
+	reflection.Register(grpcServer)
+	if cfg.GRPC.Port != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				slog.Error("grpc server stopped", "error", err)
+			}
+		}()
+	}
+
+	dispatcher := webhook.NewDispatcher(s, webhook.Config{
+		Config: worker.Config{
+			Interval:    cfg.Webhook.DispatchInterval,
+			BatchSize:   cfg.Webhook.BatchSize,
+			MaxAttempts: cfg.Webhook.MaxAttempts,
+			MinBackoff:  cfg.Webhook.MinBackoff,
+			MaxBackoff:  cfg.Webhook.MaxBackoff,
+			Timeout:     cfg.Webhook.Timeout,
+		},
+		AllowPrivateTargets: cfg.Webhook.AllowPrivateTargets,
+	})
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		dispatcher.Run(ctx)
+	}()
+
+	clients := map[api.CodeHostProvider]codehost.Client{}
+	if cfg.GitHub.Token != "" {
+		clients[api.Github] = codehost.NewGitHubClient(cfg.GitHub.APIURL, cfg.GitHub.Token, cfg.ReviewerSync.Timeout)
+	}
+	syncer := codehost.NewSyncer(s, clients, worker.Config{
+		Interval:    cfg.ReviewerSync.Interval,
+		BatchSize:   cfg.ReviewerSync.BatchSize,
+		MaxAttempts: cfg.ReviewerSync.MaxAttempts,
+		MinBackoff:  cfg.ReviewerSync.MinBackoff,
+		MaxBackoff:  cfg.ReviewerSync.MaxBackoff,
+		Timeout:     cfg.ReviewerSync.Timeout,
+	})
+	synced := make(chan struct{})
+	go func() {
+		defer close(synced)
+		if len(clients) > 0 {
+			syncer.Run(ctx)
+		}
+	}()
+
+	notifier := notify.NewChatNotifier(s, cfg.Chat.WebhookURL, templates, worker.Config{
+		Interval:    cfg.Chat.Interval,
+		BatchSize:   cfg.Chat.BatchSize,
+		MaxAttempts: cfg.Chat.MaxAttempts,
+		MinBackoff:  cfg.Chat.MinBackoff,
+		MaxBackoff:  cfg.Chat.MaxBackoff,
+		Timeout:     cfg.Chat.Timeout,
+	})
+	notified := make(chan struct{})
+	go func() {
+		defer close(notified)
+		if cfg.Chat.WebhookURL != "" {
+			notifier.Run(ctx)
+		}
+	}()
+
+	mailer := notify.NewEmailNotifier(s, emailTemplates, notify.EmailConfig{
+		Host:     cfg.Email.SMTPHost,
+		Port:     cfg.Email.SMTPPort,
+		Username: cfg.Email.SMTPUsername,
+		Password: cfg.Email.SMTPPassword,
+		From:     cfg.Email.From,
+		Config: worker.Config{
+			Interval:    cfg.Email.Interval,
+			BatchSize:   cfg.Email.BatchSize,
+			MaxAttempts: cfg.Email.MaxAttempts,
+			MinBackoff:  cfg.Email.MinBackoff,
+			MaxBackoff:  cfg.Email.MaxBackoff,
+			Timeout:     cfg.Email.Timeout,
+		},
+		ReminderInterval: cfg.Email.ReminderInterval,
+		ReminderAfter:    cfg.Email.ReminderAfter,
+	})
+	emailed := make(chan struct{})
+	go func() {
+		defer close(emailed)
+		if cfg.Email.SMTPHost != "" {
+			mailer.Run(ctx)
+		}
+	}()
+
+	snapshotter := snapshot.NewSnapshotter(s, snapshot.Config{
+		Interval: cfg.Snapshot.Interval,
+	})
+	snapshotted := make(chan struct{})
+	go func() {
+		defer close(snapshotted)
+		snapshotter.Run(ctx)
+	}()
 
 	go func() {
 		if err := e.Start(":" + cfg.Server.Port); err != nil && err != http.ErrServerClosed {
@@ -40,8 +195,19 @@ func Run(ctx context.Context, cfg *config.Config) error {
 
 	<-ctx.Done()
 
+	h.Drain()
+	time.Sleep(cfg.Server.DrainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return e.Shutdown(ctx)
+	err = e.Shutdown(ctx)
+	grpcServer.GracefulStop()
+	<-dispatched
+	<-synced
+	<-notified
+	<-emailed
+	<-snapshotted
+	<-broker.Done()
+	return err
 }
@@ -0,0 +1,184 @@
+// Package cli implements prctl, the admin command line client of the HTTP API.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"avito-trainee-task/pkg/client"
+)
+
+const (
+	defaultURL = "http://localhost:8080"
+
+	urlEnv   = "PRCTL_URL"
+	actorEnv = "PRCTL_ACTOR"
+)
+
+var errUsage = errors.New("usage")
+
+// env is passed to every command.
+type env struct {
+	client *client.Client
+	out    *printer
+}
+
+type command struct {
+	name  string
+	args  string
+	about string
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = []command{
+	{"team create", "FILE...", "Create teams from JSON or YAML files, - reads stdin", teamCreate},
+	{"team get", "TEAM", "Show team members", teamGet},
+	{"team list", "[-limit N] [-all]", "List teams", teamList},
+	{"team deactivate", "-team TEAM USER...", "Deactivate team members and reassign their reviews (team lead only)", teamDeactivate},
+	{"pr list", "[filters] [-limit N] [-all]", "List pull requests", prList},
+	{"pr get", "PR", "Show a pull request", prGet},
+	{"pr create", "-id PR -name NAME -author USER", "Create a pull request and assign reviewers", prCreate},
+	{"pr merge", "PR", "Merge a pull request", prMerge},
+	{"pr reassign", "-id PR -old USER [-reason TEXT]", "Replace a reviewer", prReassign},
+	{"user list", "[-team TEAM] [-active true|false] [-prefix P] [-limit N] [-all]", "List users", userList},
+	{"user get", "USER", "Show a user", userGet},
+	{"user activate", "USER...", "Mark users active", userActivate},
+	{"user deactivate", "USER...", "Mark users inactive", userDeactivate},
+	{"stats fairness", "[-from T] [-to T] [-team TEAM]", "Show review load spread among active team members for pull requests created in the window", fairness},
+	{"stats time-to-merge", "[-from T] [-to T] [-team TEAM] [-by team|reviewer]", "Show weekly median and p90 time to merge of pull requests merged in the window", timeToMerge},
+	{"stats", "[-from T] [-to T] [-by reviewer|team|author|pr]", "Show assignment stats of pull requests created or merged in the window", stats},
+}
+
+// Run executes prctl with args without the program name and returns
+// the exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { usage(stderr, flags) }
+
+	url := flags.String("url", envOr(urlEnv, defaultURL), "API base URL, $"+urlEnv)
+	actor := flags.String("actor", os.Getenv(actorEnv), "caller user_id sent as X-Actor-Id, $"+actorEnv)
+	format := flags.String("o", "table", "output format: table, json or yaml")
+	timeout := flags.Duration("timeout", 30*time.Second, "request timeout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cmd, rest, ok := findCommand(flags.Args())
+	if !ok {
+		flags.Usage()
+		return 2
+	}
+
+	out, err := newPrinter(stdout, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, "prctl:", err)
+		return 2
+	}
+
+	client, err := newClient(*url, *actor, *timeout)
+	if err != nil {
+		fmt.Fprintln(stderr, "prctl:", err)
+		return 1
+	}
+
+	err = cmd.run(ctx, &env{client: client, out: out}, rest)
+	switch {
+	case errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp):
+		fmt.Fprintf(stderr, "usage: prctl [flags] %s %s\n", cmd.name, cmd.args)
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "prctl %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+func findCommand(args []string) (command, []string, bool) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func usage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: prctl [flags] COMMAND [ARGS]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.about)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	flags.PrintDefaults()
+}
+
+func newClient(url, actor string, timeout time.Duration) (*client.Client, error) {
+	return client.New(
+		url,
+		client.WithHTTPClient(&http.Client{Timeout: timeout}),
+		client.WithActor(actor),
+	)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// pager holds the paging flags of list commands.
+type pager struct {
+	limit  int
+	cursor string
+	all    bool
+}
+
+func (p *pager) register(flags *flag.FlagSet) {
+	flags.IntVar(&p.limit, "limit", 0, "")
+	flags.StringVar(&p.cursor, "cursor", "", "")
+	flags.BoolVar(&p.all, "all", false, "")
+}
+
+// fetch requests pages until the last one when -all is set and returns
+// the cursor of the next page otherwise.
+func (p *pager) fetch(page func(limit *int, cursor *string) (*string, error)) (*string, error) {
+	var (
+		limit  *int
+		cursor *string
+	)
+	if p.limit > 0 {
+		limit = &p.limit
+	}
+	if p.cursor != "" {
+		cursor = &p.cursor
+	}
+
+	for {
+		next, err := page(limit, cursor)
+		if err != nil || next == nil || !p.all {
+			return next, err
+		}
+		cursor = next
+	}
+}
+
+func (p *pager) footer(w io.Writer, next *string) {
+	if next != nil {
+		fmt.Fprintf(w, "\nnext page: -cursor %s\n", *next)
+	}
+}
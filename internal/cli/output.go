@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type format string
+
+const (
+	formatTable format = "table"
+	formatJSON  format = "json"
+	formatYAML  format = "yaml"
+)
+
+type printer struct {
+	w      io.Writer
+	format format
+}
+
+func newPrinter(w io.Writer, f string) (*printer, error) {
+	switch format(f) {
+	case formatTable, formatJSON, formatYAML:
+		return &printer{w: w, format: format(f)}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", f)
+}
+
+// print writes v as JSON or YAML, or calls table with a tab separated
+// writer for the table format.
+func (p *printer) print(v any, table func(w io.Writer)) error {
+	switch p.format {
+	case formatJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatYAML:
+		// YAML goes through JSON so that it keeps the field names of the API.
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var doc any
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func row(w io.Writer, cols ...any) {
+	s := make([]string, len(cols))
+	for i, c := range cols {
+		s[i] = cell(c)
+	}
+	fmt.Fprintln(w, strings.Join(s, "\t"))
+}
+
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	case *string:
+		if v == nil {
+			return "-"
+		}
+		return cell(*v)
+	case []string:
+		if len(v) == 0 {
+			return "-"
+		}
+		return strings.Join(v, ",")
+	case *time.Time:
+		if v == nil {
+			return "-"
+		}
+		return v.Local().Format(time.DateTime)
+	}
+	return fmt.Sprint(v)
+}
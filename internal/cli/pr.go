@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+func prList(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("pr list", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var (
+		p                                            pager
+		status, author, reviewer, team               string
+		createdFrom, createdTo, mergedFrom, mergedTo timeFlag
+	)
+	p.register(flags)
+	flags.StringVar(&status, "status", "", "")
+	flags.StringVar(&author, "author", "", "")
+	flags.StringVar(&reviewer, "reviewer", "", "")
+	flags.StringVar(&team, "team", "", "")
+	flags.Var(&createdFrom, "created-from", "")
+	flags.Var(&createdTo, "created-to", "")
+	flags.Var(&mergedFrom, "merged-from", "")
+	flags.Var(&mergedTo, "merged-to", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	params := client.ListPullRequestsParams{
+		AuthorId:    optional(author),
+		ReviewerId:  optional(reviewer),
+		TeamName:    optional(team),
+		CreatedFrom: createdFrom.t,
+		CreatedTo:   createdTo.t,
+		MergedFrom:  mergedFrom.t,
+		MergedTo:    mergedTo.t,
+	}
+	if status != "" {
+		s := openapi.GetPullRequestListParamsStatus(status)
+		params.Status = &s
+	}
+
+	var prs []client.PullRequest
+	next, err := p.fetch(func(limit *int, cursor *string) (*string, error) {
+		params.Limit, params.Cursor = limit, cursor
+		page, err := e.client.ListPullRequests(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, page.PullRequests...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	list := client.PullRequestList{PullRequests: prs, NextCursor: next}
+	return e.out.print(list, func(w io.Writer) {
+		prTable(w, prs...)
+		p.footer(w, next)
+	})
+}
+
+func prGet(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	pr, sync, err := e.client.GetPullRequest(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	res := struct {
+		Pr   *client.PullRequest  `json:"pr"`
+		Sync *client.ReviewerSync `json:"sync,omitempty"`
+	}{pr, sync}
+	return e.out.print(res, func(w io.Writer) {
+		prTable(w, *pr)
+		if sync != nil {
+			fmt.Fprintf(w, "\nsync:\t%s %s#%d\t%s\t%s\n",
+				sync.Provider, sync.Repository, sync.Number, sync.Status, cell(sync.LastError))
+		}
+	})
+}
+
+func prCreate(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	id := flags.String("id", "", "")
+	name := flags.String("name", "", "")
+	author := flags.String("author", "", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || *id == "" || *name == "" || *author == "" {
+		return errUsage
+	}
+
+	pr, err := e.client.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   *id,
+		PullRequestName: *name,
+		AuthorId:        *author,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(pr, func(w io.Writer) { prTable(w, *pr) })
+}
+
+func prMerge(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	pr, err := e.client.MergePullRequest(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return e.out.print(pr, func(w io.Writer) { prTable(w, *pr) })
+}
+
+func prReassign(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("pr reassign", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	id := flags.String("id", "", "")
+	old := flags.String("old", "", "")
+	reason := flags.String("reason", "", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || *id == "" || *old == "" {
+		return errUsage
+	}
+
+	pr, replacedBy, err := e.client.ReassignPullRequest(ctx, client.ReassignPullRequestRequest{
+		PullRequestId: *id,
+		OldUserId:     *old,
+		Reason:        optional(*reason),
+	})
+	if err != nil {
+		return err
+	}
+
+	res := struct {
+		Pr         *client.PullRequest `json:"pr"`
+		ReplacedBy string              `json:"replaced_by"`
+	}{pr, replacedBy}
+	return e.out.print(res, func(w io.Writer) {
+		prTable(w, *pr)
+		fmt.Fprintf(w, "\nreplaced by:\t%s\n", replacedBy)
+	})
+}
+
+func prTable(w io.Writer, prs ...client.PullRequest) {
+	row(w, "PR", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED")
+	for _, pr := range prs {
+		row(w,
+			pr.PullRequestId,
+			pr.PullRequestName,
+			pr.AuthorId,
+			string(pr.Status),
+			pr.AssignedReviewers,
+			pr.CreatedAt,
+			pr.MergedAt,
+		)
+	}
+}
+
+// timeFlag accepts RFC 3339 timestamps and dates.
+type timeFlag struct {
+	t *time.Time
+}
+
+func (f *timeFlag) String() string {
+	if f.t == nil {
+		return ""
+	}
+	return f.t.Format(time.RFC3339)
+}
+
+func (f *timeFlag) Set(s string) error {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.ParseInLocation(time.DateOnly, s, time.Local); err != nil {
+			return err
+		}
+	}
+	f.t = &t
+	return nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
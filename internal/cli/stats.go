@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"io"
+	"time"
+
+	"avito-trainee-task/pkg/client"
+)
+
+func timeToMerge(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("stats time-to-merge", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var from, to timeFlag
+	flags.Var(&from, "from", "")
+	flags.Var(&to, "to", "")
+	team := flags.String("team", "", "")
+	by := flags.String("by", "team", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+	if *by != "team" && *by != "reviewer" {
+		return errUsage
+	}
+
+	res, err := e.client.GetTimeToMerge(ctx, client.TimeToMergeParams{
+		From:     from.t,
+		To:       to.t,
+		TeamName: optional(*team),
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res, func(w io.Writer) {
+		if *by == "reviewer" {
+			row(w, "REVIEWER", "WEEK", "MERGED", "MEDIAN", "P90")
+			for _, s := range res.Reviewers {
+				row(w, s.UserId, week(s.Week), s.MergedCount, seconds(s.MedianSeconds), seconds(s.P90Seconds))
+			}
+			return
+		}
+		row(w, "TEAM", "WEEK", "MERGED", "MEDIAN", "P90")
+		for _, s := range res.Teams {
+			row(w, s.TeamName, week(s.Week), s.MergedCount, seconds(s.MedianSeconds), seconds(s.P90Seconds))
+		}
+	})
+}
+
+func fairness(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("stats fairness", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var from, to timeFlag
+	flags.Var(&from, "from", "")
+	flags.Var(&to, "to", "")
+	team := flags.String("team", "", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	res, err := e.client.GetFairness(ctx, client.FairnessParams{
+		From:     from.t,
+		To:       to.t,
+		TeamName: optional(*team),
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res, func(w io.Writer) {
+		row(w, "TEAM", "MEMBERS", "ASSIGNMENTS", "MEAN", "MIN", "MAX", "STDDEV", "GINI", "OVER MEAN", "UNDER MEAN")
+		for _, s := range res.Teams {
+			row(w, s.TeamName, s.MemberCount, s.AssignmentCount, s.Mean, s.Min, s.Max, s.Stddev, s.Gini, s.OverMean, s.UnderMean)
+		}
+	})
+}
+
+func week(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+func seconds(s int) time.Duration {
+	return time.Duration(s) * time.Second
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"avito-trainee-task/pkg/client"
+
+	"gopkg.in/yaml.v3"
+)
+
+func teamCreate(ctx context.Context, e *env, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	var created []client.Team
+	for _, name := range args {
+		teams, err := readTeams(name)
+		if err != nil {
+			return err
+		}
+		for _, team := range teams {
+			res, err := e.client.AddTeam(ctx, team)
+			if err != nil {
+				return fmt.Errorf("team %s: %w", team.TeamName, err)
+			}
+			created = append(created, *res)
+		}
+	}
+
+	return e.out.print(created, func(w io.Writer) {
+		row(w, "TEAM", "MEMBERS")
+		for _, team := range created {
+			row(w, team.TeamName, len(team.Members))
+		}
+	})
+}
+
+// readTeams decodes a team or a list of teams from a JSON or YAML file.
+func readTeams(name string) ([]client.Team, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, the document is converted to JSON to
+	// reuse the field names of the generated types.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var teams []client.Team
+	if _, ok := doc.([]any); ok {
+		err = json.Unmarshal(raw, &teams)
+	} else {
+		teams = make([]client.Team, 1)
+		err = json.Unmarshal(raw, &teams[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return teams, nil
+}
+
+func teamGet(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	team, err := e.client.GetTeam(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return e.out.print(team, func(w io.Writer) {
+		row(w, "USER", "USERNAME", "ROLE", "ACTIVE")
+		for _, m := range team.Members {
+			role := ""
+			if m.Role != nil {
+				role = string(*m.Role)
+			}
+			row(w, m.UserId, m.Username, role, m.IsActive)
+		}
+	})
+}
+
+func teamList(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("team list", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var p pager
+	p.register(flags)
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	var teams []client.TeamSummary
+	next, err := p.fetch(func(limit *int, cursor *string) (*string, error) {
+		page, err := e.client.ListTeams(ctx, client.ListTeamsParams{Limit: limit, Cursor: cursor})
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, page.Teams...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	list := client.TeamList{Teams: teams, NextCursor: next}
+	return e.out.print(list, func(w io.Writer) {
+		row(w, "TEAM", "MEMBERS", "ACTIVE", "OPEN PRS")
+		for _, t := range teams {
+			row(w, t.TeamName, t.MemberCount, t.ActiveMemberCount, t.OpenPullRequestCount)
+		}
+		p.footer(w, next)
+	})
+}
+
+func teamDeactivate(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("team deactivate", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	team := flags.String("team", "", "")
+	if err := flags.Parse(args); err != nil || *team == "" || flags.NArg() == 0 {
+		return errUsage
+	}
+
+	result, err := e.client.DeactivateTeamMembers(ctx, *team, flags.Args()...)
+	if err != nil {
+		return err
+	}
+
+	return e.out.print(result, func(w io.Writer) {
+		row(w, "PR", "OLD REVIEWER", "NEW REVIEWER")
+		for _, r := range result.Reassignments {
+			row(w, r.PullRequestId, r.OldUserId, r.ReplacedBy)
+		}
+		fmt.Fprintf(w, "\ndeactivated: %s\n", cell(result.DeactivatedUserIds))
+	})
+}
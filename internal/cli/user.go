@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strconv"
+
+	"avito-trainee-task/pkg/client"
+)
+
+func userList(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("user list", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var (
+		p                    pager
+		team, active, prefix string
+	)
+	p.register(flags)
+	flags.StringVar(&team, "team", "", "")
+	flags.StringVar(&active, "active", "", "")
+	flags.StringVar(&prefix, "prefix", "", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	params := client.ListUsersParams{
+		TeamName:       optional(team),
+		UsernamePrefix: optional(prefix),
+	}
+	if active != "" {
+		v, err := strconv.ParseBool(active)
+		if err != nil {
+			return errUsage
+		}
+		params.IsActive = &v
+	}
+
+	var users []client.UserDetails
+	next, err := p.fetch(func(limit *int, cursor *string) (*string, error) {
+		params.Limit, params.Cursor = limit, cursor
+		page, err := e.client.ListUsers(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page.Users...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	list := client.UserList{Users: users, NextCursor: next}
+	return e.out.print(list, func(w io.Writer) {
+		userTable(w, users...)
+		p.footer(w, next)
+	})
+}
+
+func userGet(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	user, err := e.client.GetUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return e.out.print(user, func(w io.Writer) { userTable(w, *user) })
+}
+
+func userActivate(ctx context.Context, e *env, args []string) error {
+	return setIsActive(ctx, e, args, true)
+}
+
+func userDeactivate(ctx context.Context, e *env, args []string) error {
+	return setIsActive(ctx, e, args, false)
+}
+
+func setIsActive(ctx context.Context, e *env, ids []string, active bool) error {
+	if len(ids) == 0 {
+		return errUsage
+	}
+
+	users := make([]client.User, 0, len(ids))
+	for _, id := range ids {
+		user, err := e.client.SetIsActive(ctx, id, active)
+		if err != nil {
+			return err
+		}
+		users = append(users, *user)
+	}
+
+	return e.out.print(users, func(w io.Writer) {
+		row(w, "USER", "USERNAME", "TEAM", "ACTIVE")
+		for _, u := range users {
+			row(w, u.UserId, u.Username, u.TeamName, u.IsActive)
+		}
+	})
+}
+
+func userTable(w io.Writer, users ...client.UserDetails) {
+	row(w, "USER", "USERNAME", "TEAM", "ACTIVE", "OPEN REVIEWS")
+	for _, u := range users {
+		row(w, u.UserId, u.Username, u.TeamName, u.IsActive, u.OpenReviewCount)
+	}
+}
+
+func stats(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var from, to timeFlag
+	flags.Var(&from, "from", "")
+	flags.Var(&to, "to", "")
+	by := flags.String("by", "reviewer", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	var table func(w io.Writer, res *client.AssignmentCountStat)
+	switch *by {
+	case "reviewer":
+		table = reviewerStats
+	case "team":
+		table = teamStats
+	case "author":
+		table = authorStats
+	case "pr":
+		table = pullRequestStats
+	default:
+		return errUsage
+	}
+
+	res, err := e.client.GetStats(ctx, client.StatsParams{From: from.t, To: to.t})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res, func(w io.Writer) { table(w, res) })
+}
+
+func reviewerStats(w io.Writer, res *client.AssignmentCountStat) {
+	row(w, "REVIEWER", "ASSIGNMENTS", "OPEN", "MERGED")
+	for _, s := range res.Stats {
+		row(w, s.UserId, s.AssignmentCount, s.OpenCount, s.MergedCount)
+	}
+}
+
+func teamStats(w io.Writer, res *client.AssignmentCountStat) {
+	row(w, "TEAM", "PRS", "OPEN", "MERGED", "REASSIGNMENTS")
+	for _, s := range res.Teams {
+		row(w, s.TeamName, s.PullRequestCount, s.OpenCount, s.MergedCount, s.ReassignmentCount)
+	}
+}
+
+func authorStats(w io.Writer, res *client.AssignmentCountStat) {
+	row(w, "AUTHOR", "PRS", "OPEN", "MERGED")
+	for _, s := range res.Authors {
+		row(w, s.UserId, s.PullRequestCount, s.OpenCount, s.MergedCount)
+	}
+}
+
+func pullRequestStats(w io.Writer, res *client.AssignmentCountStat) {
+	row(w, "PR", "AUTHOR", "STATUS", "REVIEWERS", "REASSIGNMENTS")
+	for _, s := range res.PullRequests {
+		row(w, s.PullRequestId, s.AuthorId, string(s.Status), s.ReviewerCount, s.ReassignmentCount)
+	}
+}
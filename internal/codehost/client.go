@@ -0,0 +1,45 @@
+package codehost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Client applies reviewer assignments to pull requests on a code host.
+// Repository is the full name of the repository, e.g. owner/repo.
+type Client interface {
+	RequestReviewers(ctx context.Context, repository string, number int, logins []string) error
+	RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error
+}
+
+// StatusError is returned for unsuccessful code host responses.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed when repeated.
+// Client errors other than timeouts and rate limiting are permanent.
+func Retryable(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+
+	switch code := statusErr.StatusCode; {
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests:
+		return true
+	case code >= 400 && code < 500:
+		return false
+	}
+	return true
+}
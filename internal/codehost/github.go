@@ -0,0 +1,88 @@
+package codehost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"avito-trainee-task/internal/worker"
+)
+
+const (
+	gitHubAPIVersion = "2022-11-28"
+	maxMessageLength = 512
+)
+
+// GitHubClient requests reviewers through the GitHub REST API.
+type GitHubClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewGitHubClient(baseURL, token string, timeout time.Duration) *GitHubClient {
+	return &GitHubClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *GitHubClient) RequestReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	return c.requestedReviewers(ctx, http.MethodPost, repository, number, logins)
+}
+
+func (c *GitHubClient) RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	return c.requestedReviewers(ctx, http.MethodDelete, repository, number, logins)
+}
+
+func (c *GitHubClient) requestedReviewers(
+	ctx context.Context,
+	method, repository string,
+	number int,
+	logins []string,
+) error {
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok {
+		return fmt.Errorf("codehost.GitHubClient: invalid repository %q", repository)
+	}
+
+	body, err := json.Marshal(map[string][]string{"reviewers": logins})
+	if err != nil {
+		return fmt.Errorf("codehost.GitHubClient failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/requested_reviewers",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), number)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", gitHubAPIVersion)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil
+	}
+
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&apiErr)
+	return &StatusError{StatusCode: resp.StatusCode, Message: worker.Truncate(apiErr.Message, maxMessageLength)}
+}
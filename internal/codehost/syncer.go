@@ -0,0 +1,115 @@
+package codehost
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/worker"
+)
+
+type Store interface {
+	ClaimReviewerSyncs(
+		ctx context.Context,
+		providers []api.CodeHostProvider,
+		limit int,
+		lease time.Duration,
+	) ([]postgres.PendingReviewerSync, error)
+	MarkReviewerSynced(ctx context.Context, prId string, version int64, reviewers []string) error
+	MarkReviewerSyncFailed(
+		ctx context.Context,
+		prId string,
+		version int64,
+		lastError string,
+		retryIn *time.Duration,
+	) error
+}
+
+// Syncer applies reviewer assignments of linked pull requests to their code
+// hosts. Reviewers requested by the previous sync and no longer assigned are
+// removed, so repeated syncs converge to the assignment in the service.
+type Syncer struct {
+	store   Store
+	clients map[api.CodeHostProvider]Client
+	cfg     worker.Config
+}
+
+func NewSyncer(store Store, clients map[api.CodeHostProvider]Client, cfg worker.Config) *Syncer {
+	return &Syncer{
+		store:   store,
+		clients: clients,
+		cfg:     cfg,
+	}
+}
+
+// Run syncs due pull requests every interval until ctx is done.
+func (s *Syncer) Run(ctx context.Context) {
+	worker.Run(ctx, s.cfg.Interval, "failed to sync reviewers", func(ctx context.Context) error {
+		_, err := s.SyncOnce(ctx)
+		return err
+	})
+}
+
+// SyncOnce syncs one batch of due pull requests and returns its size.
+func (s *Syncer) SyncOnce(ctx context.Context) (int, error) {
+	providers := slices.Sorted(maps.Keys(s.clients))
+	syncs, err := s.store.ClaimReviewerSyncs(ctx, providers, s.cfg.BatchSize, 2*s.cfg.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, pending := range syncs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.sync(ctx, pending); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "failed to record reviewer sync",
+					"pull_request_id", pending.PullRequestId,
+					"error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return len(syncs), nil
+}
+
+func (s *Syncer) sync(ctx context.Context, pending postgres.PendingReviewerSync) error {
+	err := s.apply(ctx, pending)
+	if err == nil {
+		return s.store.MarkReviewerSynced(ctx, pending.PullRequestId, pending.Version, pending.Reviewers)
+	}
+
+	retryIn := s.cfg.RetryIn(pending.Attempts+1, Retryable(err))
+	return s.store.MarkReviewerSyncFailed(ctx, pending.PullRequestId, pending.Version, worker.ErrorMessage(err), retryIn)
+}
+
+func (s *Syncer) apply(ctx context.Context, pending postgres.PendingReviewerSync) error {
+	client := s.clients[pending.Provider]
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	var removed []string
+	for _, login := range pending.SyncedReviewers {
+		if !slices.Contains(pending.Reviewers, login) {
+			removed = append(removed, login)
+		}
+	}
+	if len(removed) > 0 {
+		if err := client.RemoveReviewers(ctx, pending.Repository, pending.Number, removed); err != nil {
+			return err
+		}
+	}
+
+	if len(pending.Reviewers) > 0 {
+		return client.RequestReviewers(ctx, pending.Repository, pending.Number, pending.Reviewers)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package integrations
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/webhook"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	GitHubWebhookPath = "/integrations/github/webhook"
+
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+type gitHubUser struct {
+	Login string `json:"login"`
+}
+
+type gitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title    string      `json:"title"`
+		User     gitHubUser  `json:"user"`
+		Merged   bool        `json:"merged"`
+		MergedBy *gitHubUser `json:"merged_by"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// GitHubHandler maps GitHub pull request webhooks onto the pull request
+// lifecycle: opened PRs are created with reviewers assigned, merged PRs
+// are merged. Authors are resolved through code host account mappings.
+type GitHubHandler struct {
+	s      Storage
+	secret string
+}
+
+func NewGitHubHandler(s Storage, secret string) *GitHubHandler {
+	return &GitHubHandler{
+		s:      s,
+		secret: secret,
+	}
+}
+
+func (h *GitHubHandler) RegisterRoutes(e *echo.Echo) {
+	e.POST(GitHubWebhookPath, h.Webhook)
+}
+
+// Webhook handles POST /integrations/github/webhook.
+func (h *GitHubHandler) Webhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxPayloadSize))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	signature := c.Request().Header.Get(GitHubSignatureHeader)
+	if !hmac.Equal([]byte(signature), []byte(webhook.Sign(h.secret, body))) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid signature")
+	}
+
+	var result Result
+	switch event := c.Request().Header.Get(GitHubEventHeader); event {
+	case "ping":
+		result = Result{Status: StatusPong}
+	case "pull_request":
+		var payload gitHubPullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return echo.ErrBadRequest
+		}
+		result, err = h.handlePullRequest(ctx, payload)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to handle github pull request event",
+				"repository", payload.Repository.FullName,
+				"number", payload.Number,
+				"action", payload.Action,
+				"error", err)
+			return echo.ErrInternalServerError
+		}
+	default:
+		result = ignored("unsupported event " + event)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GitHubHandler) handlePullRequest(ctx context.Context, event gitHubPullRequestEvent) (Result, error) {
+	switch {
+	case event.Action == "opened":
+		return h.open(ctx, event)
+	case event.Action == "closed" && event.PullRequest.Merged:
+		return h.merge(ctx, event)
+	}
+	return ignored("unsupported action " + event.Action), nil
+}
+
+func (h *GitHubHandler) open(ctx context.Context, event gitHubPullRequestEvent) (Result, error) {
+	link := postgres.ExternalPullRequest{
+		Provider:      api.Github,
+		Repository:    event.Repository.FullName,
+		Number:        event.Number,
+		PullRequestId: fmt.Sprintf("github:%s#%d", event.Repository.FullName, event.Number),
+	}
+	return openPullRequest(ctx, h.s, link, event.PullRequest.Title, event.PullRequest.User.Login)
+}
+
+func (h *GitHubHandler) merge(ctx context.Context, event gitHubPullRequestEvent) (Result, error) {
+	var actorLogin string
+	if by := event.PullRequest.MergedBy; by != nil {
+		actorLogin = by.Login
+	}
+	return mergePullRequest(ctx, h.s, api.Github, event.Repository.FullName, event.Number, actorLogin)
+}
@@ -0,0 +1,117 @@
+package integrations
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	GitLabWebhookPath = "/integrations/gitlab/webhook"
+
+	GitLabEventHeader = "X-Gitlab-Event"
+	GitLabTokenHeader = "X-Gitlab-Token"
+
+	gitLabMergeRequestHook = "Merge Request Hook"
+)
+
+type gitLabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		Iid    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// GitLabHandler maps GitLab merge request hooks onto the pull request
+// lifecycle. The hook carries only the numeric id of the author, so
+// the user who triggered the event is taken as the author of opened
+// and reopened merge requests.
+type GitLabHandler struct {
+	s     Storage
+	token string
+}
+
+func NewGitLabHandler(s Storage, token string) *GitLabHandler {
+	return &GitLabHandler{
+		s:     s,
+		token: token,
+	}
+}
+
+func (h *GitLabHandler) RegisterRoutes(e *echo.Echo) {
+	e.POST(GitLabWebhookPath, h.Webhook)
+}
+
+// Webhook handles POST /integrations/gitlab/webhook.
+func (h *GitLabHandler) Webhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	token := c.Request().Header.Get(GitLabTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	if event := c.Request().Header.Get(GitLabEventHeader); event != gitLabMergeRequestHook {
+		return c.JSON(http.StatusOK, ignored("unsupported event "+event))
+	}
+
+	var payload gitLabMergeRequestEvent
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxPayloadSize))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	result, err := h.handleMergeRequest(ctx, payload)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to handle gitlab merge request event",
+			"project", payload.Project.PathWithNamespace,
+			"iid", payload.ObjectAttributes.Iid,
+			"action", payload.ObjectAttributes.Action,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GitLabHandler) handleMergeRequest(ctx context.Context, event gitLabMergeRequestEvent) (Result, error) {
+	project, iid := event.Project.PathWithNamespace, event.ObjectAttributes.Iid
+	switch action := event.ObjectAttributes.Action; action {
+	case "open", "reopen":
+		link := postgres.ExternalPullRequest{
+			Provider:      api.Gitlab,
+			Repository:    project,
+			Number:        iid,
+			PullRequestId: fmt.Sprintf("gitlab:%s!%d", project, iid),
+		}
+		return openPullRequest(ctx, h.s, link, event.ObjectAttributes.Title, event.User.Username)
+	case "merge":
+		return mergePullRequest(ctx, h.s, api.Gitlab, project, iid, event.User.Username)
+	case "close":
+		// Pull requests are either open or merged, a closed merge
+		// request keeps its reviewers until it is reopened and merged.
+		return ignored("closing without merge is not supported"), nil
+	default:
+		return ignored("unsupported action " + action), nil
+	}
+}
@@ -0,0 +1,110 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/metrics"
+	"avito-trainee-task/internal/storage/postgres"
+)
+
+// maxPayloadSize bounds webhook bodies by the cap GitHub puts on payloads.
+const maxPayloadSize = 25 << 20
+
+type Storage interface {
+	CreatePullRequest(ctx context.Context, req api.PostPullRequestCreateJSONBody, actorId string) (*api.PullRequest, error)
+	Merge(ctx context.Context, pullRequestId, actorId string) (*api.PullRequest, error)
+
+	GetCodeHostUserId(ctx context.Context, provider api.CodeHostProvider, login string) (string, error)
+	LinkExternalPullRequest(ctx context.Context, link postgres.ExternalPullRequest) error
+	GetExternalPullRequestId(ctx context.Context, provider api.CodeHostProvider, repository string, number int) (string, error)
+}
+
+// Result is the body returned to the code host. Events that do not
+// change state are acknowledged as ignored with the reason.
+type Result struct {
+	Status        string `json:"status"`
+	PullRequestId string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+const (
+	StatusCreated = "created"
+	StatusMerged  = "merged"
+	StatusIgnored = "ignored"
+	StatusPong    = "pong"
+)
+
+// openPullRequest creates the pull request authored by the mapped login
+// and links it to its origin. Redelivered events find the pull request
+// created and only complete the link in case the first attempt failed
+// midway, so they never fail with PR_EXISTS.
+func openPullRequest(
+	ctx context.Context,
+	s Storage,
+	link postgres.ExternalPullRequest,
+	title, authorLogin string,
+) (Result, error) {
+	authorId, err := s.GetCodeHostUserId(ctx, link.Provider, authorLogin)
+	if errors.Is(err, postgres.ErrAccountNotFound) {
+		return ignored("author " + authorLogin + " is not mapped to a user"), nil
+	} else if err != nil {
+		return Result{}, err
+	}
+
+	pr, err := s.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		PullRequestId:   link.PullRequestId,
+		PullRequestName: title,
+		AuthorId:        authorId,
+	}, authorId)
+	switch {
+	case err == nil:
+		metrics.ObserveAssignment(len(pr.AssignedReviewers))
+	case errors.Is(err, postgres.ErrUserNotFound):
+		return ignored("author " + authorId + " not found"), nil
+	case err != nil && !errors.Is(err, postgres.ErrPullRequestExists):
+		return Result{}, err
+	}
+
+	if err := s.LinkExternalPullRequest(ctx, link); err != nil {
+		return Result{}, err
+	}
+	return Result{Status: StatusCreated, PullRequestId: link.PullRequestId}, nil
+}
+
+// mergePullRequest merges the linked pull request on behalf of the mapped
+// login. Merging is idempotent in storage, unmapped logins merge without
+// an actor.
+func mergePullRequest(
+	ctx context.Context,
+	s Storage,
+	provider api.CodeHostProvider,
+	repository string,
+	number int,
+	actorLogin string,
+) (Result, error) {
+	prId, err := s.GetExternalPullRequestId(ctx, provider, repository, number)
+	if errors.Is(err, postgres.ErrPullRequestNotFound) {
+		return ignored("pull request is not tracked"), nil
+	} else if err != nil {
+		return Result{}, err
+	}
+
+	var actorId string
+	if actorLogin != "" {
+		actorId, err = s.GetCodeHostUserId(ctx, provider, actorLogin)
+		if err != nil && !errors.Is(err, postgres.ErrAccountNotFound) {
+			return Result{}, err
+		}
+	}
+
+	if _, err := s.Merge(ctx, prId, actorId); err != nil {
+		return Result{}, err
+	}
+	return Result{Status: StatusMerged, PullRequestId: prId}, nil
+}
+
+func ignored(reason string) Result {
+	return Result{Status: StatusIgnored, Reason: reason}
+}
@@ -0,0 +1,81 @@
+package scim
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var errInvalidFilter = errors.New("invalid filter")
+
+var memberPathRe = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
+
+// condition is a single `attribute eq value` comparison. Only equality
+// joined with `and` is supported, which is what identity providers send.
+type condition struct {
+	Attribute string
+	Value     string
+}
+
+func parseFilter(filter string) ([]condition, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []condition
+	for i := 0; i < len(tokens); i += 4 {
+		if i > 0 {
+			if !strings.EqualFold(tokens[i-1], "and") {
+				return nil, errInvalidFilter
+			}
+		}
+		if i+2 >= len(tokens) || !strings.EqualFold(tokens[i+1], "eq") {
+			return nil, errInvalidFilter
+		}
+		conditions = append(conditions, condition{
+			Attribute: strings.ToLower(tokens[i]),
+			Value:     tokens[i+2],
+		})
+		if i+3 == len(tokens) {
+			return conditions, nil
+		}
+	}
+	return nil, errInvalidFilter
+}
+
+func tokenize(filter string) ([]string, error) {
+	var tokens []string
+	for rest := strings.TrimSpace(filter); rest != ""; rest = strings.TrimSpace(rest) {
+		if rest[0] != '"' {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, rest[:end])
+			rest = rest[end:]
+			continue
+		}
+
+		prefix, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil, errInvalidFilter
+		}
+		value, err := strconv.Unquote(prefix)
+		if err != nil {
+			return nil, errInvalidFilter
+		}
+		tokens = append(tokens, value)
+		rest = rest[len(prefix):]
+	}
+	return tokens, nil
+}
+
+func memberFromPath(path string) (string, bool) {
+	m := memberPathRe.FindStringSubmatch(path)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
@@ -0,0 +1,234 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListGroups handles GET /Groups.
+func (h *Handler) ListGroups(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var names []string
+	expr := c.QueryParam("filter")
+	if expr != "" {
+		conditions, err := parseFilter(expr)
+		if err != nil || len(conditions) != 1 ||
+			(conditions[0].Attribute != "id" && conditions[0].Attribute != "displayname") {
+			return newError(http.StatusBadRequest, "invalidFilter", "only id or displayName equality is supported")
+		}
+		names = []string{conditions[0].Value}
+	} else {
+		params := api.GetTeamListParams{}
+		for {
+			page, err := h.s.ListTeams(ctx, params)
+			if err != nil {
+				return internalError(ctx, "failed to list scim groups", "error", err)
+			}
+			for _, t := range page.Teams {
+				names = append(names, t.TeamName)
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+	}
+
+	startIndex, offset, limit := pagination(c)
+	total := len(names)
+	names = names[min(offset, total):min(offset+limit, total)]
+
+	excludeMembers := expr == "" && strings.Contains(strings.ToLower(c.QueryParam("excludedAttributes")), "members")
+	resources := make([]Group, 0, len(names))
+	for _, name := range names {
+		team := &api.Team{TeamName: name}
+		if !excludeMembers {
+			var err error
+			team, err = h.s.GetTeam(ctx, name)
+			if errors.Is(err, postgres.ErrTeamNotFound) {
+				total--
+				continue
+			} else if err != nil {
+				return internalError(ctx, "failed to get scim group", "team_name", name, "error", err)
+			}
+		}
+		resources = append(resources, newGroup(*team, baseURL(c)))
+	}
+
+	return scimJSON(c, http.StatusOK, newListResponse(resources, total, startIndex))
+}
+
+// GetGroup handles GET /Groups/:id.
+func (h *Handler) GetGroup(c echo.Context) error {
+	team, err := h.getTeam(c, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return scimJSON(c, http.StatusOK, newGroup(*team, baseURL(c)))
+}
+
+// CreateGroup handles POST /Groups. Teams exist only through their members,
+// so a group without members is rejected instead of being created unseen.
+func (h *Handler) CreateGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req Group
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return invalidSyntax()
+	} else if req.DisplayName == "" {
+		return newError(http.StatusBadRequest, "invalidValue", "displayName is required")
+	} else if len(req.Members) == 0 {
+		return newError(http.StatusBadRequest, "invalidValue", "group must have members")
+	}
+
+	_, err := h.s.GetTeam(ctx, req.DisplayName)
+	if err == nil {
+		return newError(http.StatusConflict, "uniqueness", "group already exists")
+	} else if !errors.Is(err, postgres.ErrTeamNotFound) {
+		return internalError(ctx, "failed to get scim group", "team_name", req.DisplayName, "error", err)
+	}
+
+	if err := h.setMembers(c, req.DisplayName, memberIds(req.Members), nil); err != nil {
+		return err
+	}
+	return h.respondGroup(c, http.StatusCreated, req.DisplayName)
+}
+
+// ReplaceGroup handles PUT /Groups/:id.
+func (h *Handler) ReplaceGroup(c echo.Context) error {
+	var req Group
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return invalidSyntax()
+	}
+
+	team, err := h.getTeam(c, c.Param("id"))
+	if err != nil {
+		return err
+	} else if req.DisplayName != "" && req.DisplayName != team.TeamName {
+		return newError(http.StatusBadRequest, "mutability", "groups cannot be renamed")
+	}
+
+	add := memberIds(req.Members)
+	if err := h.setMembers(c, team.TeamName, add, removedMembers(team, add)); err != nil {
+		return err
+	}
+	return h.respondGroup(c, http.StatusOK, team.TeamName)
+}
+
+// PatchGroup handles PATCH /Groups/:id.
+func (h *Handler) PatchGroup(c echo.Context) error {
+	var req PatchRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return invalidSyntax()
+	}
+
+	team, err := h.getTeam(c, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	var add, remove []string
+	for _, op := range req.Operations {
+		var refs []Reference
+		if len(op.Value) > 0 && op.Path != "" {
+			if err := json.Unmarshal(op.Value, &refs); err != nil {
+				return invalidSyntax()
+			}
+		}
+
+		switch {
+		case strings.EqualFold(op.Op, "add") && strings.EqualFold(op.Path, "members"):
+			add = append(add, memberIds(refs)...)
+		case strings.EqualFold(op.Op, "remove") && strings.EqualFold(op.Path, "members"):
+			if len(refs) == 0 {
+				remove = append(remove, removedMembers(team, nil)...)
+			}
+			remove = append(remove, memberIds(refs)...)
+		case strings.EqualFold(op.Op, "remove"):
+			id, ok := memberFromPath(op.Path)
+			if !ok {
+				return newError(http.StatusBadRequest, "invalidPath", "unsupported path "+op.Path)
+			}
+			remove = append(remove, id)
+		case strings.EqualFold(op.Op, "replace") && strings.EqualFold(op.Path, "members"):
+			add = memberIds(refs)
+			remove = removedMembers(team, add)
+		case strings.EqualFold(op.Op, "replace") && op.Path == "":
+			var value Group
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return invalidSyntax()
+			} else if value.DisplayName != "" && value.DisplayName != team.TeamName {
+				return newError(http.StatusBadRequest, "mutability", "groups cannot be renamed")
+			}
+			if value.Members != nil {
+				add = memberIds(value.Members)
+				remove = removedMembers(team, add)
+			}
+		default:
+			return newError(http.StatusBadRequest, "invalidPath", "unsupported operation "+op.Op+" "+op.Path)
+		}
+	}
+
+	remove = slices.DeleteFunc(remove, func(id string) bool {
+		return slices.Contains(add, id)
+	})
+	if err := h.setMembers(c, team.TeamName, add, remove); err != nil {
+		return err
+	}
+	return h.respondGroup(c, http.StatusOK, team.TeamName)
+}
+
+func (h *Handler) getTeam(c echo.Context, name string) (*api.Team, error) {
+	ctx := c.Request().Context()
+
+	team, err := h.s.GetTeam(ctx, name)
+	if errors.Is(err, postgres.ErrTeamNotFound) {
+		return nil, newError(http.StatusNotFound, "", "group not found")
+	} else if err != nil {
+		return nil, internalError(ctx, "failed to get scim group", "team_name", name, "error", err)
+	}
+	return team, nil
+}
+
+func (h *Handler) setMembers(c echo.Context, teamName string, add, remove []string) error {
+	ctx := c.Request().Context()
+
+	err := h.s.SetTeamMembers(ctx, teamName, add, remove, h.defaultTeam)
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return newError(http.StatusBadRequest, "invalidValue", "group member not found")
+	} else if err != nil {
+		return internalError(ctx, "failed to set scim group members", "team_name", teamName, "error", err)
+	}
+	return nil
+}
+
+func (h *Handler) respondGroup(c echo.Context, status int, teamName string) error {
+	ctx := c.Request().Context()
+
+	team, err := h.s.GetTeam(ctx, teamName)
+	if errors.Is(err, postgres.ErrTeamNotFound) {
+		team = &api.Team{TeamName: teamName}
+	} else if err != nil {
+		return internalError(ctx, "failed to get scim group", "team_name", teamName, "error", err)
+	}
+	return scimJSON(c, status, newGroup(*team, baseURL(c)))
+}
+
+func removedMembers(team *api.Team, keep []string) []string {
+	var removed []string
+	for _, m := range team.Members {
+		if !slices.Contains(keep, m.UserId) {
+			removed = append(removed, m.UserId)
+		}
+	}
+	return removed
+}
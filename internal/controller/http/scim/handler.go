@@ -0,0 +1,162 @@
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	BasePath = "/scim/v2"
+
+	maxResults = 200
+)
+
+type Storage interface {
+	GetUser(ctx context.Context, userId string) (*api.UserDetails, error)
+	FindUsers(ctx context.Context, filter postgres.UserFilter, offset, limit int) ([]api.User, int, error)
+	CreateUser(ctx context.Context, user api.User) (*api.User, error)
+	UpdateUser(ctx context.Context, user api.User) (*api.User, error)
+
+	GetTeam(ctx context.Context, teamName string) (*api.Team, error)
+	ListTeams(ctx context.Context, params api.GetTeamListParams) (*api.TeamList, error)
+	SetTeamMembers(ctx context.Context, teamName string, add, remove []string, fallbackTeam string) error
+}
+
+// Handler serves SCIM 2.0 provisioning of users and groups. Groups map to
+// teams, users removed from a group are moved to the default team.
+type Handler struct {
+	s           Storage
+	token       string
+	defaultTeam string
+}
+
+func NewHandler(s Storage, token, defaultTeam string) *Handler {
+	return &Handler{
+		s:           s,
+		token:       token,
+		defaultTeam: defaultTeam,
+	}
+}
+
+func (h *Handler) RegisterRoutes(e *echo.Echo) {
+	g := e.Group(BasePath, renderErrors, h.authenticate)
+
+	g.GET("/ServiceProviderConfig", h.GetServiceProviderConfig)
+
+	g.GET("/Users", h.ListUsers)
+	g.POST("/Users", h.CreateUser)
+	g.GET("/Users/:id", h.GetUser)
+	g.PUT("/Users/:id", h.ReplaceUser)
+	g.PATCH("/Users/:id", h.PatchUser)
+	g.DELETE("/Users/:id", h.DeleteUser)
+
+	g.GET("/Groups", h.ListGroups)
+	g.POST("/Groups", h.CreateGroup)
+	g.GET("/Groups/:id", h.GetGroup)
+	g.PUT("/Groups/:id", h.ReplaceGroup)
+	g.PATCH("/Groups/:id", h.PatchGroup)
+}
+
+func (h *Handler) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			return newError(http.StatusUnauthorized, "", "invalid bearer token")
+		}
+		return next(c)
+	}
+}
+
+// GetServiceProviderConfig advertises the supported subset of SCIM.
+func (h *Handler) GetServiceProviderConfig(c echo.Context) error {
+	supported := func(ok bool) map[string]bool {
+		return map[string]bool{"supported": ok}
+	}
+	return scimJSON(c, http.StatusOK, map[string]any{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]string{{
+			"type": "oauthbearertoken",
+			"name": "OAuth Bearer Token",
+		}},
+	})
+}
+
+func scimJSON(c echo.Context, status int, body any) error {
+	c.Response().Header().Set(echo.HeaderContentType, ContentType)
+	c.Response().WriteHeader(status)
+	return json.NewEncoder(c.Response()).Encode(body)
+}
+
+func newError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+func invalidSyntax() *Error {
+	return newError(http.StatusBadRequest, "invalidSyntax", "malformed request body")
+}
+
+func internalError(ctx context.Context, msg string, args ...any) *Error {
+	slog.ErrorContext(ctx, msg, args...)
+	return newError(http.StatusInternalServerError, "", "internal error")
+}
+
+// renderErrors writes handler errors as SCIM error bodies.
+func renderErrors(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		if err == nil || c.Response().Committed {
+			return err
+		}
+
+		var scimErr *Error
+		var httpErr *echo.HTTPError
+		switch {
+		case errors.As(err, &scimErr):
+		case errors.As(err, &httpErr):
+			scimErr = newError(httpErr.Code, "", http.StatusText(httpErr.Code))
+		default:
+			scimErr = internalError(c.Request().Context(), "scim request failed", "error", err)
+		}
+
+		status, _ := strconv.Atoi(scimErr.Status)
+		return scimJSON(c, status, scimErr)
+	}
+}
+
+func baseURL(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host + BasePath
+}
+
+// pagination converts SCIM 1-based startIndex and count into an offset page.
+func pagination(c echo.Context) (startIndex, offset, limit int) {
+	startIndex, limit = 1, maxResults
+	if v, err := strconv.Atoi(c.QueryParam("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(c.QueryParam("count")); err == nil && v >= 0 && v < maxResults {
+		limit = v
+	}
+	return startIndex, startIndex - 1, limit
+}
@@ -0,0 +1,144 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+
+	"avito-trainee-task/internal/api"
+)
+
+const (
+	UserSchema       = "urn:ietf:params:scim:schemas:core:2.0:User"
+	EnterpriseSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	GroupSchema      = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListSchema       = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema    = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema      = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ContentType = "application/scim+json"
+)
+
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+type Reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type Enterprise struct {
+	Department string `json:"department,omitempty"`
+}
+
+type User struct {
+	Schemas    []string    `json:"schemas"`
+	Id         string      `json:"id,omitempty"`
+	ExternalId string      `json:"externalId,omitempty"`
+	UserName   string      `json:"userName"`
+	Active     *bool       `json:"active,omitempty"`
+	Groups     []Reference `json:"groups,omitempty"`
+	Enterprise *Enterprise `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta       *Meta       `json:"meta,omitempty"`
+}
+
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	Id          string      `json:"id,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []Reference `json:"members"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func newUser(u api.User, baseURL string) User {
+	active := u.IsActive
+	return User{
+		Schemas:    []string{UserSchema, EnterpriseSchema},
+		Id:         u.UserId,
+		ExternalId: u.UserId,
+		UserName:   u.Username,
+		Active:     &active,
+		Groups:     []Reference{{Value: u.TeamName, Display: u.TeamName}},
+		Enterprise: &Enterprise{Department: u.TeamName},
+		Meta: &Meta{
+			ResourceType: "User",
+			Location:     baseURL + "/Users/" + u.UserId,
+		},
+	}
+}
+
+func newGroup(t api.Team, baseURL string) Group {
+	members := make([]Reference, 0, len(t.Members))
+	for _, m := range t.Members {
+		members = append(members, Reference{Value: m.UserId, Display: m.Username})
+	}
+	return Group{
+		Schemas:     []string{GroupSchema},
+		Id:          t.TeamName,
+		DisplayName: t.TeamName,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Location:     baseURL + "/Groups/" + t.TeamName,
+		},
+	}
+}
+
+func newListResponse[T any](resources []T, total, startIndex int) ListResponse {
+	if resources == nil {
+		resources = []T{}
+	}
+	return ListResponse{
+		Schemas:      []string{ListSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func memberIds(refs []Reference) []string {
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ids = append(ids, r.Value)
+	}
+	return ids
+}
+
+// isEnterpriseDepartment matches both the short and the fully qualified
+// path of the enterprise department attribute.
+func isEnterpriseDepartment(path string) bool {
+	return strings.EqualFold(path, "department") ||
+		strings.EqualFold(path, EnterpriseSchema+":department")
+}
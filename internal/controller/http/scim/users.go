@@ -0,0 +1,264 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListUsers handles GET /Users.
+func (h *Handler) ListUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter, err := userFilter(c.QueryParam("filter"))
+	if err != nil {
+		return err
+	}
+
+	startIndex, offset, limit := pagination(c)
+	users, total, err := h.s.FindUsers(ctx, filter, offset, limit)
+	if err != nil {
+		return internalError(ctx, "failed to find scim users", "error", err)
+	}
+
+	resources := make([]User, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, newUser(u, baseURL(c)))
+	}
+	return scimJSON(c, http.StatusOK, newListResponse(resources, total, startIndex))
+}
+
+// GetUser handles GET /Users/:id.
+func (h *Handler) GetUser(c echo.Context) error {
+	user, err := h.getUser(c)
+	if err != nil {
+		return err
+	}
+	return scimJSON(c, http.StatusOK, newUser(*user, baseURL(c)))
+}
+
+// CreateUser handles POST /Users.
+func (h *Handler) CreateUser(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req User
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return invalidSyntax()
+	} else if req.UserName == "" {
+		return newError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+
+	user := api.User{
+		UserId:   req.ExternalId,
+		Username: req.UserName,
+		TeamName: h.defaultTeam,
+		IsActive: req.Active == nil || *req.Active,
+	}
+	if user.UserId == "" {
+		user.UserId = req.UserName
+	}
+	if req.Enterprise != nil && req.Enterprise.Department != "" {
+		user.TeamName = req.Enterprise.Department
+	}
+
+	created, err := h.s.CreateUser(ctx, user)
+	if errors.Is(err, postgres.ErrUserExists) {
+		return newError(http.StatusConflict, "uniqueness", "user already exists")
+	} else if err != nil {
+		return internalError(ctx, "failed to create scim user", "user_id", user.UserId, "error", err)
+	}
+
+	return scimJSON(c, http.StatusCreated, newUser(*created, baseURL(c)))
+}
+
+// ReplaceUser handles PUT /Users/:id.
+func (h *Handler) ReplaceUser(c echo.Context) error {
+	var req User
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return invalidSyntax()
+	} else if req.UserName == "" {
+		return newError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+
+	user, err := h.getUser(c)
+	if err != nil {
+		return err
+	}
+
+	user.Username = req.UserName
+	if req.Active != nil {
+		user.IsActive = *req.Active
+	}
+	if req.Enterprise != nil && req.Enterprise.Department != "" {
+		user.TeamName = req.Enterprise.Department
+	}
+	return h.updateUser(c, *user)
+}
+
+// PatchUser handles PATCH /Users/:id.
+func (h *Handler) PatchUser(c echo.Context) error {
+	var req PatchRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return invalidSyntax()
+	}
+
+	user, err := h.getUser(c)
+	if err != nil {
+		return err
+	}
+
+	for _, op := range req.Operations {
+		if !strings.EqualFold(op.Op, "add") && !strings.EqualFold(op.Op, "replace") {
+			return newError(http.StatusBadRequest, "invalidValue", "unsupported operation "+op.Op)
+		}
+
+		attributes := map[string]json.RawMessage{op.Path: op.Value}
+		if op.Path == "" {
+			attributes = nil
+			if err := json.Unmarshal(op.Value, &attributes); err != nil {
+				return invalidSyntax()
+			}
+		}
+
+		for path, value := range attributes {
+			if err := applyUserAttribute(user, path, value); err != nil {
+				return newError(http.StatusBadRequest, "invalidValue", err.Error())
+			}
+		}
+	}
+
+	return h.updateUser(c, *user)
+}
+
+// DeleteUser handles DELETE /Users/:id. Users are referenced by pull
+// requests, so deprovisioning only deactivates them.
+func (h *Handler) DeleteUser(c echo.Context) error {
+	user, err := h.getUser(c)
+	if err != nil {
+		return err
+	}
+
+	user.IsActive = false
+	if _, err := h.s.UpdateUser(c.Request().Context(), *user); err != nil {
+		return internalError(c.Request().Context(), "failed to deactivate scim user",
+			"user_id", user.UserId,
+			"error", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) getUser(c echo.Context) (*api.User, error) {
+	ctx := c.Request().Context()
+
+	details, err := h.s.GetUser(ctx, c.Param("id"))
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return nil, newError(http.StatusNotFound, "", "user not found")
+	} else if err != nil {
+		return nil, internalError(ctx, "failed to get scim user", "user_id", c.Param("id"), "error", err)
+	}
+
+	return &api.User{
+		UserId:   details.UserId,
+		Username: details.Username,
+		TeamName: details.TeamName,
+		IsActive: details.IsActive,
+	}, nil
+}
+
+func (h *Handler) updateUser(c echo.Context, user api.User) error {
+	ctx := c.Request().Context()
+
+	updated, err := h.s.UpdateUser(ctx, user)
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return newError(http.StatusNotFound, "", "user not found")
+	} else if err != nil {
+		return internalError(ctx, "failed to update scim user", "user_id", user.UserId, "error", err)
+	}
+
+	return scimJSON(c, http.StatusOK, newUser(*updated, baseURL(c)))
+}
+
+func userFilter(expr string) (postgres.UserFilter, error) {
+	var filter postgres.UserFilter
+	if expr == "" {
+		return filter, nil
+	}
+
+	conditions, err := parseFilter(expr)
+	if err != nil {
+		return filter, newError(http.StatusBadRequest, "invalidFilter", "unsupported filter expression")
+	}
+
+	for _, cond := range conditions {
+		switch cond.Attribute {
+		case "id", "externalid":
+			filter.UserId = &cond.Value
+		case "username":
+			filter.Username = &cond.Value
+		case "active":
+			active, err := strconv.ParseBool(cond.Value)
+			if err != nil {
+				return filter, newError(http.StatusBadRequest, "invalidFilter", "active must be boolean")
+			}
+			filter.IsActive = &active
+		default:
+			return filter, newError(http.StatusBadRequest, "invalidFilter", "unsupported filter attribute "+cond.Attribute)
+		}
+	}
+	return filter, nil
+}
+
+// applyUserAttribute sets the attribute at path. Identity providers patch
+// attributes the service doesn't store, such as name.givenName, so unknown
+// paths are ignored.
+func applyUserAttribute(user *api.User, path string, value json.RawMessage) error {
+	switch {
+	case strings.EqualFold(path, "active"):
+		active, err := boolValue(value)
+		if err != nil {
+			return err
+		}
+		user.IsActive = active
+	case strings.EqualFold(path, "userName"):
+		if err := json.Unmarshal(value, &user.Username); err != nil {
+			return errors.New("userName must be string")
+		}
+	case isEnterpriseDepartment(path):
+		if err := json.Unmarshal(value, &user.TeamName); err != nil {
+			return errors.New("department must be string")
+		}
+	case strings.EqualFold(path, EnterpriseSchema):
+		var ext Enterprise
+		if err := json.Unmarshal(value, &ext); err != nil {
+			return errors.New("invalid enterprise extension")
+		}
+		if ext.Department != "" {
+			user.TeamName = ext.Department
+		}
+	}
+	return nil
+}
+
+// boolValue accepts both JSON booleans and the "True"/"False" strings
+// some identity providers send.
+func boolValue(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, errors.New("active must be boolean")
+}
@@ -0,0 +1,118 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	v1 "avito-trainee-task/internal/controller/http/v1"
+	"avito-trainee-task/internal/events"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	EventsStreamPath = "/events/stream"
+
+	// LastEventIdHeader is sent by EventSource clients on reconnect with
+	// the id of the last event they received.
+	LastEventIdHeader = "Last-Event-ID"
+
+	// retryDelay is the reconnect delay suggested to clients.
+	retryDelay = 3 * time.Second
+)
+
+type Storage interface {
+	CheckEventFilter(ctx context.Context, filter postgres.EventFilter) error
+	LastEventId(ctx context.Context) (int64, error)
+	ListEventsAfter(ctx context.Context, afterId int64, filter postgres.EventFilter, limit int) ([]api.AssignmentEvent, error)
+}
+
+// Handler streams assignment events as Server-Sent Events. Every event
+// is sent with its event_id as the SSE id, so a reconnecting client
+// resumes from the event log without gaps.
+type Handler struct {
+	s         Storage
+	broker    *events.Broker
+	keepAlive time.Duration
+}
+
+func NewHandler(s Storage, broker *events.Broker, keepAlive time.Duration) *Handler {
+	return &Handler{
+		s:         s,
+		broker:    broker,
+		keepAlive: keepAlive,
+	}
+}
+
+func (h *Handler) RegisterRoutes(e *echo.Echo) {
+	e.GET(EventsStreamPath, h.Stream)
+}
+
+// Stream handles GET /events/stream?user_id=&team_name=.
+func (h *Handler) Stream(c echo.Context) error {
+	ctx := c.Request().Context()
+	filter := postgres.EventFilter{
+		UserId:   c.QueryParam("user_id"),
+		TeamName: c.QueryParam("team_name"),
+	}
+
+	err := h.s.CheckEventFilter(ctx, filter)
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, v1.NewError(api.NOTFOUND, "User not found"))
+	} else if errors.Is(err, postgres.ErrTeamNotFound) {
+		return c.JSON(http.StatusNotFound, v1.NewError(api.NOTFOUND, "Team not found"))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to check event filter", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	var after int64
+	if lastId := c.Request().Header.Get(LastEventIdHeader); lastId != "" {
+		after, err = strconv.ParseInt(lastId, 10, 64)
+		if err != nil || after < 0 {
+			return c.JSON(http.StatusBadRequest, v1.NewError(api.BADREQUEST, "invalid Last-Event-ID"))
+		}
+	} else {
+		after, err = h.s.LastEventId(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get last event id", "error", err)
+			return echo.ErrInternalServerError
+		}
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retryDelay.Milliseconds())
+	w.Flush()
+
+	err = h.broker.Follow(ctx, h.s, filter, after, h.keepAlive, func(event api.AssignmentEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.EventId, event.EventType, data)
+		w.Flush()
+		return err
+	}, func() error {
+		// Comments keep proxies from closing an idle stream.
+		_, err := fmt.Fprint(w, ": keep-alive\n\n")
+		w.Flush()
+		return err
+	})
+	if err != nil && ctx.Err() == nil {
+		slog.ErrorContext(ctx, "failed to stream events", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/migration"
+
+	"github.com/labstack/echo/v4"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// Drain makes readiness fail so that the orchestrator stops routing
+// traffic before the server shuts down.
+func (h *Handler) Drain() {
+	h.draining.Store(true)
+}
+
+// GetHealthz implements api.ServerInterface.
+func (h *Handler) GetHealthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, api.Liveness{Status: api.HealthOk})
+}
+
+// GetReadyz implements api.ServerInterface.
+func (h *Handler) GetReadyz(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]api.HealthCheck{
+		"database":   h.checkDatabase(ctx),
+		"migrations": h.checkMigrations(ctx),
+		"draining":   h.checkDraining(),
+	}
+
+	res := api.Readiness{Status: api.HealthOk, Checks: checks}
+	for _, check := range checks {
+		if check.Status != api.HealthOk {
+			res.Status = api.HealthFail
+			return c.JSON(http.StatusServiceUnavailable, res)
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) checkDatabase(ctx context.Context) api.HealthCheck {
+	if err := h.s.Ping(ctx); err != nil {
+		return failed(err.Error())
+	}
+	return api.HealthCheck{Status: api.HealthOk}
+}
+
+// checkMigrations passes when the schema is at least at the version the
+// server was built with, newer migrations of a rolling deploy add to
+// the schema without breaking older servers.
+func (h *Handler) checkMigrations(ctx context.Context) api.HealthCheck {
+	expected, err := migration.ExpectedVersion()
+	if err != nil {
+		return failed(err.Error())
+	}
+
+	version, dirty, err := h.s.MigrationVersion(ctx)
+	switch {
+	case err != nil:
+		return failed(err.Error())
+	case dirty:
+		return failed(fmt.Sprintf("dirty at version %d", version))
+	case version < expected:
+		return failed(fmt.Sprintf("version %d, expected %d", version, expected))
+	}
+	msg := fmt.Sprintf("version %d", version)
+	return api.HealthCheck{Status: api.HealthOk, Message: &msg}
+}
+
+func (h *Handler) checkDraining() api.HealthCheck {
+	if h.draining.Load() {
+		return failed("shutting down")
+	}
+	return api.HealthCheck{Status: api.HealthOk}
+}
+
+func failed(msg string) api.HealthCheck {
+	return api.HealthCheck{Status: api.HealthFail, Message: &msg}
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetPullRequestHistory implements api.ServerInterface.
+func (h *Handler) GetPullRequestHistory(c echo.Context, params api.GetPullRequestHistoryParams) error {
+	ctx := c.Request().Context()
+	events, err := h.s.GetPullRequestHistory(ctx, params)
+	switch {
+	case errors.Is(err, postgres.ErrPullRequestNotFound):
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Pull request not found",
+		))
+	case errors.Is(err, postgres.ErrInvalidCursor), errors.Is(err, postgres.ErrInvalidListParams):
+		return echo.ErrBadRequest
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to get pull request history",
+			"pull_request_id", params.PullRequestId,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, events)
+}
+
+// GetUsersHistory implements api.ServerInterface.
+func (h *Handler) GetUsersHistory(c echo.Context, params api.GetUsersHistoryParams) error {
+	ctx := c.Request().Context()
+	events, err := h.s.GetUserHistory(ctx, params)
+	switch {
+	case errors.Is(err, postgres.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "User not found",
+		))
+	case errors.Is(err, postgres.ErrInvalidCursor), errors.Is(err, postgres.ErrInvalidListParams):
+		return echo.ErrBadRequest
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to get user history",
+			"user_id", params.UserId,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, events)
+}
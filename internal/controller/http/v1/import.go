@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/orgchart"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PostImport implements api.ServerInterface.
+func (h *Handler) PostImport(c echo.Context, params api.PostImportParams) error {
+	ctx := c.Request().Context()
+
+	teams, err := orgchart.Parse(
+		c.Request().Header.Get(echo.HeaderContentType),
+		c.Request().Body,
+	)
+	if errors.Is(err, orgchart.ErrUnsupportedFormat) {
+		return echo.ErrUnsupportedMediaType
+	} else if err != nil {
+		return c.JSON(http.StatusBadRequest, NewError(
+			api.BADREQUEST, err.Error(),
+		))
+	}
+
+	// Applying a chart is limited to leads of the teams it changes,
+	// the report of a dry run is open to anyone.
+	dryRun := params.DryRun != nil && *params.DryRun
+	if !dryRun {
+		if ok, err := h.requireLead(c, "apply an import"); !ok {
+			return err
+		}
+		if ok, err := h.authorizeImport(c, teams, params); !ok {
+			return err
+		}
+	}
+
+	report, err := h.s.ImportTeams(ctx, teams, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to import teams", "dry_run", dryRun, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// authorizeImport responds with 403 unless the caller leads every team the
+// chart changes: the teams it lists, the teams users are moved from and the
+// teams of deactivated users. Teams that don't exist yet are open to any lead.
+// When ok is false the handler returns err as is.
+func (h *Handler) authorizeImport(c echo.Context, teams []api.Team, params api.PostImportParams) (ok bool, err error) {
+	ctx := c.Request().Context()
+	actor := actorId(c)
+
+	dryRun := true
+	plan, err := h.s.ImportTeams(ctx, teams, api.PostImportParams{
+		DryRun:            &dryRun,
+		DeactivateMissing: params.DeactivateMissing,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to plan import", "error", err)
+		return false, echo.ErrInternalServerError
+	}
+
+	deactivated, err := h.s.GetUserTeams(ctx, plan.Deactivated)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get user teams", "error", err)
+		return false, echo.ErrInternalServerError
+	}
+
+	touched := make([]string, 0, len(teams)+len(plan.Moved)+len(deactivated))
+	for _, t := range teams {
+		touched = append(touched, t.TeamName)
+	}
+	for _, m := range plan.Moved {
+		touched = append(touched, m.FromTeam)
+	}
+	for _, teamName := range deactivated {
+		touched = append(touched, teamName)
+	}
+	slices.Sort(touched)
+
+	for _, teamName := range slices.Compact(touched) {
+		isLead, err := h.s.IsTeamLead(ctx, actor, teamName)
+		if errors.Is(err, postgres.ErrTeamNotFound) {
+			continue
+		} else if err != nil {
+			slog.ErrorContext(ctx, "failed to check team lead", "user_id", actor, "error", err)
+			return false, echo.ErrInternalServerError
+		} else if !isLead {
+			return false, c.JSON(http.StatusForbidden, NewError(
+				api.FORBIDDEN, "Only team lead of "+teamName+" can apply an import",
+			))
+		}
+	}
+	return true, nil
+}
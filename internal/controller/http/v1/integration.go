@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PostIntegrationsAccountsSet implements api.ServerInterface.
+func (h *Handler) PostIntegrationsAccountsSet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.CodeHostAccount
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	if ok, err := h.requireLead(c, "map code host accounts"); !ok {
+		return err
+	}
+
+	if !isKnownProvider(req.Provider) {
+		return c.JSON(http.StatusBadRequest, NewError(
+			api.BADREQUEST, "unknown provider "+string(req.Provider),
+		))
+	}
+	if strings.TrimSpace(req.Login) == "" {
+		return c.JSON(http.StatusBadRequest, NewError(
+			api.BADREQUEST, "login must not be empty",
+		))
+	}
+
+	account, err := h.s.SetCodeHostAccount(ctx, req)
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "User not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to set code host account",
+			"provider", req.Provider,
+			"login", req.Login,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Account api.CodeHostAccount `json:"account"`
+	}{
+		Account: *account,
+	})
+}
+
+// GetIntegrationsAccountsList implements api.ServerInterface.
+func (h *Handler) GetIntegrationsAccountsList(c echo.Context, params api.GetIntegrationsAccountsListParams) error {
+	ctx := c.Request().Context()
+	accounts, err := h.s.ListCodeHostAccounts(ctx, params.Provider)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list code host accounts", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Accounts []api.CodeHostAccount `json:"accounts"`
+	}{
+		Accounts: accounts,
+	})
+}
+
+// PostIntegrationsAccountsDelete implements api.ServerInterface.
+func (h *Handler) PostIntegrationsAccountsDelete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostIntegrationsAccountsDeleteJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	if ok, err := h.requireLead(c, "map code host accounts"); !ok {
+		return err
+	}
+
+	account, err := h.s.DeleteCodeHostAccount(ctx, req.Provider, req.Login)
+	if errors.Is(err, postgres.ErrAccountNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Account not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to delete code host account",
+			"provider", req.Provider,
+			"login", req.Login,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Account api.CodeHostAccount `json:"account"`
+	}{
+		Account: *account,
+	})
+}
+
+// PostIntegrationsSyncRetry implements api.ServerInterface.
+func (h *Handler) PostIntegrationsSyncRetry(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostIntegrationsSyncRetryJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	sync, err := h.s.RetryReviewerSync(ctx, req.PullRequestId)
+	if errors.Is(err, postgres.ErrPullRequestNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Pull request not linked to a code host",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to retry reviewer sync",
+			"pull_request_id", req.PullRequestId,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Sync api.ReviewerSync `json:"sync"`
+	}{
+		Sync: *sync,
+	})
+}
+
+func isKnownProvider(p api.CodeHostProvider) bool {
+	switch p {
+	case api.Github, api.Gitlab:
+		return true
+	}
+	return false
+}
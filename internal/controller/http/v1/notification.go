@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PostNotificationsContactsSet implements api.ServerInterface.
+func (h *Handler) PostNotificationsContactsSet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.NotificationContact
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	if isEmpty(req.ChatChannel) && isEmpty(req.ChatHandle) && (req.Email == nil || *req.Email == "") {
+		return c.JSON(http.StatusBadRequest, NewError(
+			api.BADREQUEST, "chat_channel, chat_handle or email is required",
+		))
+	}
+	if req.EmailOptOut != nil {
+		for _, kind := range *req.EmailOptOut {
+			if !isKnownNotificationKind(kind) {
+				return c.JSON(http.StatusBadRequest, NewError(
+					api.BADREQUEST, "unknown notification kind "+string(kind),
+				))
+			}
+		}
+	}
+
+	contact, err := h.s.SetNotificationContact(ctx, req)
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "User not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to set notification contact", "user_id", req.UserId, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Contact api.NotificationContact `json:"contact"`
+	}{
+		Contact: *contact,
+	})
+}
+
+// GetNotificationsContactsList implements api.ServerInterface.
+func (h *Handler) GetNotificationsContactsList(c echo.Context) error {
+	ctx := c.Request().Context()
+	contacts, err := h.s.ListNotificationContacts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list notification contacts", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Contacts []api.NotificationContact `json:"contacts"`
+	}{
+		Contacts: contacts,
+	})
+}
+
+// PostNotificationsContactsDelete implements api.ServerInterface.
+func (h *Handler) PostNotificationsContactsDelete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostNotificationsContactsDeleteJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	contact, err := h.s.DeleteNotificationContact(ctx, req.UserId)
+	if errors.Is(err, postgres.ErrContactNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Contact not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to delete notification contact", "user_id", req.UserId, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Contact api.NotificationContact `json:"contact"`
+	}{
+		Contact: *contact,
+	})
+}
+
+func isEmpty(s *string) bool {
+	return s == nil || *s == ""
+}
+
+func isKnownNotificationKind(kind api.NotificationKind) bool {
+	switch kind {
+	case api.KindAssigned, api.KindUnassigned, api.KindMerged, api.KindReminder:
+		return true
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/metrics"
 	"avito-trainee-task/internal/storage/postgres"
 
 	"github.com/labstack/echo/v4"
@@ -23,7 +24,7 @@ func (h *Handler) PostPullRequestCreate(c echo.Context) error {
 		return echo.ErrBadRequest
 	}
 
-	pr, err := h.s.CreatePullRequest(ctx, req)
+	pr, err := h.s.CreatePullRequest(ctx, req, actorId(c))
 	switch {
 	case errors.Is(err, postgres.ErrUserNotFound):
 		return c.JSON(http.StatusNotFound, NewError(
@@ -37,6 +38,7 @@ func (h *Handler) PostPullRequestCreate(c echo.Context) error {
 		slog.ErrorContext(ctx, "failed to create pull request", "error", err)
 		return echo.ErrInternalServerError
 	}
+	metrics.ObserveAssignment(len(pr.AssignedReviewers))
 
 	return c.JSON(http.StatusCreated, &struct {
 		PR api.PullRequest `json:"pr"`
@@ -56,7 +58,29 @@ func (h *Handler) PostPullRequestMerge(c echo.Context) error {
 		return echo.ErrBadRequest
 	}
 
-	pr, err := h.s.Merge(ctx, req.PullRequestId)
+	if actor := actorId(c); actor != "" {
+		teamName, err := h.s.GetPullRequestTeamName(ctx, req.PullRequestId)
+		if errors.Is(err, postgres.ErrPullRequestNotFound) {
+			return c.JSON(http.StatusNotFound, NewError(
+				api.NOTFOUND, "Pull request not found",
+			))
+		} else if err != nil {
+			slog.ErrorContext(ctx, "failed to get pull request team", "error", err)
+			return echo.ErrInternalServerError
+		}
+
+		isLead, err := h.s.IsTeamLead(ctx, actor, teamName)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check team lead", "user_id", actor, "error", err)
+			return echo.ErrInternalServerError
+		} else if !isLead {
+			return c.JSON(http.StatusForbidden, NewError(
+				api.FORBIDDEN, "Only team lead can merge pull requests",
+			))
+		}
+	}
+
+	pr, err := h.s.Merge(ctx, req.PullRequestId, actorId(c))
 	if errors.Is(err, postgres.ErrPullRequestNotFound) {
 		return c.JSON(http.StatusNotFound, NewError(
 			api.NOTFOUND, "Pull request not found",
@@ -85,11 +109,29 @@ func (h *Handler) PostPullRequestReassign(c echo.Context) error {
 		return echo.ErrBadRequest
 	}
 
-	pr, new, err := h.s.Reassign(
-		c.Request().Context(),
-		req.PullRequestId,
-		req.OldUserId,
-	)
+	if actor := actorId(c); actor != "" && actor != req.OldUserId {
+		teamName, err := h.s.GetPullRequestTeamName(ctx, req.PullRequestId)
+		if errors.Is(err, postgres.ErrPullRequestNotFound) {
+			return c.JSON(http.StatusNotFound, NewError(
+				api.NOTFOUND, "Pull request not found",
+			))
+		} else if err != nil {
+			slog.ErrorContext(ctx, "failed to get pull request team", "error", err)
+			return echo.ErrInternalServerError
+		}
+
+		isLead, err := h.s.IsTeamLead(ctx, actor, teamName)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check team lead", "user_id", actor, "error", err)
+			return echo.ErrInternalServerError
+		} else if !isLead {
+			return c.JSON(http.StatusForbidden, NewError(
+				api.FORBIDDEN, "Only team lead can reassign other reviewers",
+			))
+		}
+	}
+
+	pr, new, err := h.s.Reassign(ctx, req, actorId(c))
 
 	switch {
 	case errors.Is(err, postgres.ErrReassignMergedPullRequest):
@@ -101,6 +143,7 @@ func (h *Handler) PostPullRequestReassign(c echo.Context) error {
 			api.NOTASSIGNED, "Reviewer is not assigned to this PR",
 		))
 	case errors.Is(err, postgres.ErrNoCandidate):
+		metrics.ObserveReassignment(true)
 		return c.JSON(http.StatusConflict, NewError(
 			api.NOCANDIDATE, "No active replacement candidate in team",
 		))
@@ -117,6 +160,7 @@ func (h *Handler) PostPullRequestReassign(c echo.Context) error {
 			"error", err)
 		return echo.ErrInternalServerError
 	}
+	metrics.ObserveReassignment(false)
 
 	return c.JSON(http.StatusOK, &struct {
 		PR         api.PullRequest `json:"pr"`
@@ -126,3 +170,51 @@ func (h *Handler) PostPullRequestReassign(c echo.Context) error {
 		ReplacedBy: new,
 	})
 }
+
+// GetPullRequestGet implements api.ServerInterface.
+func (h *Handler) GetPullRequestGet(c echo.Context, params api.GetPullRequestGetParams) error {
+	ctx := c.Request().Context()
+	pr, err := h.s.GetPullRequestById(ctx, params.PullRequestId)
+	if errors.Is(err, postgres.ErrPullRequestNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Pull request not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to get pull request", "pull_request_id", params.PullRequestId, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	sync, err := h.s.GetReviewerSync(ctx, params.PullRequestId)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get reviewer sync", "pull_request_id", params.PullRequestId, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	history, err := h.s.GetPullRequestHistory(ctx, api.GetPullRequestHistoryParams{
+		PullRequestId: params.PullRequestId,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get pull request history", "pull_request_id", params.PullRequestId, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &api.PullRequestDetails{
+		Pr:      *pr,
+		Sync:    sync,
+		History: *history,
+	})
+}
+
+// GetPullRequestList implements api.ServerInterface.
+func (h *Handler) GetPullRequestList(c echo.Context, params api.GetPullRequestListParams) error {
+	ctx := c.Request().Context()
+	prs, err := h.s.ListPullRequests(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidCursor) || errors.Is(err, postgres.ErrInvalidListParams) {
+		return echo.ErrBadRequest
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to list pull requests", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, prs)
+}
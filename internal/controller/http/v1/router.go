@@ -3,6 +3,8 @@ package v1
 import (
 	"context"
 	"log/slog"
+	"net/http"
+	"sync/atomic"
 	"time"
 
 	"avito-trainee-task/internal/api"
@@ -12,19 +14,60 @@ import (
 
 type Storage interface {
 	SetIsActive(ctx context.Context, UserId string, isActive bool) (*api.User, error)
-	GetReview(ctx context.Context, userId string) ([]*api.PullRequestShort, error)
-	GetUsersStats(ctx context.Context) (*api.AssignmentCountStat, error)
+	GetReview(ctx context.Context, params api.GetUsersGetReviewParams) (*api.ReviewList, error)
+	GetUsersStats(ctx context.Context, params api.GetUsersStatsParams) (*api.AssignmentCountStat, error)
+	GetUser(ctx context.Context, userId string) (*api.UserDetails, error)
+	ListUsers(ctx context.Context, params api.GetUsersListParams) (*api.UserList, error)
+	GetTimeToMerge(ctx context.Context, params api.GetStatsTimeToMergeParams) (*api.TimeToMergeStat, error)
+	GetFairness(ctx context.Context, params api.GetStatsFairnessParams) (*api.FairnessStat, error)
 
 	GetTeam(ctx context.Context, teamName string) (*api.Team, error)
+	ListTeams(ctx context.Context, params api.GetTeamListParams) (*api.TeamList, error)
+	IsTeamLead(ctx context.Context, userId, teamName string) (bool, error)
+	DeactivateTeamMembers(ctx context.Context, teamName string, userIds []string, actorId string) (*api.DeactivationResult, error)
+	IsLead(ctx context.Context, userId string) (bool, error)
+	HasLead(ctx context.Context) (bool, error)
+	GetUserTeams(ctx context.Context, userIds []string) (map[string]string, error)
+	ImportTeams(ctx context.Context, teams []api.Team, params api.PostImportParams) (*api.ImportReport, error)
 	AddTeam(ctx context.Context, team api.Team) (*api.Team, error)
 
-	Merge(ctx context.Context, pullRequestId string) (*api.PullRequest, error)
-	Reassign(ctx context.Context, pullRequestId, userId string) (*api.PullRequest, string, error)
-	CreatePullRequest(ctx context.Context, req api.PostPullRequestCreateJSONBody) (*api.PullRequest, error)
+	Merge(ctx context.Context, pullRequestId, actorId string) (*api.PullRequest, error)
+	Reassign(ctx context.Context, req api.PostPullRequestReassignJSONBody, actorId string) (*api.PullRequest, string, error)
+	CreatePullRequest(ctx context.Context, req api.PostPullRequestCreateJSONBody, actorId string) (*api.PullRequest, error)
+	GetPullRequestTeamName(ctx context.Context, prId string) (string, error)
+	GetPullRequestById(ctx context.Context, prId string) (*api.PullRequest, error)
+	ListPullRequests(ctx context.Context, params api.GetPullRequestListParams) (*api.PullRequestList, error)
+
+	GetPullRequestHistory(ctx context.Context, params api.GetPullRequestHistoryParams) (*api.AssignmentEventList, error)
+	GetUserHistory(ctx context.Context, params api.GetUsersHistoryParams) (*api.AssignmentEventList, error)
+
+	CreateWebhookSubscription(ctx context.Context, req api.PostWebhooksSubscribeJSONBody) (*api.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionId int64) (*api.WebhookSubscription, error)
+	ListWebhookDeliveries(ctx context.Context, params api.GetWebhooksDeliveriesParams) (*api.WebhookDeliveryList, error)
+	RedeliverWebhook(ctx context.Context, deliveryId int64) (*api.WebhookDelivery, error)
+
+	SetCodeHostAccount(ctx context.Context, account api.CodeHostAccount) (*api.CodeHostAccount, error)
+	ListCodeHostAccounts(ctx context.Context, provider *api.CodeHostProvider) ([]api.CodeHostAccount, error)
+	DeleteCodeHostAccount(ctx context.Context, provider api.CodeHostProvider, login string) (*api.CodeHostAccount, error)
+	GetReviewerSync(ctx context.Context, prId string) (*api.ReviewerSync, error)
+	RetryReviewerSync(ctx context.Context, prId string) (*api.ReviewerSync, error)
+
+	SetNotificationContact(ctx context.Context, contact api.NotificationContact) (*api.NotificationContact, error)
+	ListNotificationContacts(ctx context.Context) ([]api.NotificationContact, error)
+	DeleteNotificationContact(ctx context.Context, userId string) (*api.NotificationContact, error)
+
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
 }
 
+// ActorHeader carries user_id of the caller performing the request.
+const ActorHeader = "X-Actor-Id"
+
 type Handler struct {
 	s Storage
+
+	draining atomic.Bool
 }
 
 func NewHandler(s Storage) *Handler {
@@ -66,6 +109,33 @@ func LoggingMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+func actorId(c echo.Context) string {
+	return c.Request().Header.Get(ActorHeader)
+}
+
+// requireLead responds with 403 unless the caller is an active team lead.
+// When ok is false the handler returns err as is.
+func (h *Handler) requireLead(c echo.Context, action string) (ok bool, err error) {
+	ctx := c.Request().Context()
+	actor := actorId(c)
+	if actor == "" {
+		return false, c.JSON(http.StatusForbidden, NewError(
+			api.FORBIDDEN, "Caller identity required",
+		))
+	}
+
+	isLead, err := h.s.IsLead(ctx, actor)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check lead", "user_id", actor, "error", err)
+		return false, echo.ErrInternalServerError
+	} else if !isLead {
+		return false, c.JSON(http.StatusForbidden, NewError(
+			api.FORBIDDEN, "Only team lead can "+action,
+		))
+	}
+	return true, nil
+}
+
 type ErrorResponseBody struct {
 	Code    api.ErrorResponseErrorCode `json:"code"`
 	Message string                     `json:"message"`
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"encoding/csv"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetStatsTimeToMerge implements api.ServerInterface.
+func (h *Handler) GetStatsTimeToMerge(c echo.Context, params api.GetStatsTimeToMergeParams) error {
+	ctx := c.Request().Context()
+	stat, err := h.s.GetTimeToMerge(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidListParams) {
+		return echo.ErrBadRequest
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to get time to merge", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	if !acceptsCSV(c) {
+		return c.JSON(http.StatusOK, stat)
+	}
+
+	table := api.TimeToMergeTableTeams
+	if params.Table != nil {
+		table = *params.Table
+	}
+	records, ok := timeToMergeCSV(stat, table)
+	if !ok {
+		return echo.ErrBadRequest
+	}
+	return writeCSV(c, records)
+}
+
+// GetStatsFairness implements api.ServerInterface.
+func (h *Handler) GetStatsFairness(c echo.Context, params api.GetStatsFairnessParams) error {
+	ctx := c.Request().Context()
+	stat, err := h.s.GetFairness(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidListParams) {
+		return echo.ErrBadRequest
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to get fairness", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	if !acceptsCSV(c) {
+		return c.JSON(http.StatusOK, stat)
+	}
+
+	table := api.FairnessTableTeams
+	if params.Table != nil {
+		table = *params.Table
+	}
+	records, ok := fairnessCSV(stat, table)
+	if !ok {
+		return echo.ErrBadRequest
+	}
+	return writeCSV(c, records)
+}
+
+// usersStatsCSV lays out a table of the stats as CSV records with
+// the header first.
+func usersStatsCSV(stat *api.AssignmentCountStat, table api.GetUsersStatsParamsTable) ([][]string, bool) {
+	var records [][]string
+	switch table {
+	case api.UsersStatsTableReviewers:
+		records = append(records, []string{"user_id", "assignment_count", "open_count", "merged_count"})
+		for _, s := range stat.Stats {
+			records = append(records, []string{s.UserId, itoa(s.AssignmentCount), itoa(s.OpenCount), itoa(s.MergedCount)})
+		}
+	case api.UsersStatsTableTeams:
+		records = append(records, []string{"team_name", "pull_request_count", "open_count", "merged_count", "reassignment_count"})
+		for _, s := range stat.Teams {
+			records = append(records, []string{
+				s.TeamName, itoa(s.PullRequestCount), itoa(s.OpenCount), itoa(s.MergedCount), itoa(s.ReassignmentCount),
+			})
+		}
+	case api.UsersStatsTableAuthors:
+		records = append(records, []string{"user_id", "pull_request_count", "open_count", "merged_count"})
+		for _, s := range stat.Authors {
+			records = append(records, []string{s.UserId, itoa(s.PullRequestCount), itoa(s.OpenCount), itoa(s.MergedCount)})
+		}
+	case api.UsersStatsTablePullRequests:
+		records = append(records, []string{"pull_request_id", "author_id", "status", "reviewer_count", "reassignment_count"})
+		for _, s := range stat.PullRequests {
+			records = append(records, []string{
+				s.PullRequestId, s.AuthorId, string(s.Status), itoa(s.ReviewerCount), itoa(s.ReassignmentCount),
+			})
+		}
+	default:
+		return nil, false
+	}
+	return records, true
+}
+
+func timeToMergeCSV(stat *api.TimeToMergeStat, table api.GetStatsTimeToMergeParamsTable) ([][]string, bool) {
+	var records [][]string
+	switch table {
+	case api.TimeToMergeTableTeams:
+		records = append(records, []string{"team_name", "week", "merged_count", "median_seconds", "p90_seconds"})
+		for _, s := range stat.Teams {
+			records = append(records, []string{
+				s.TeamName, csvTime(s.Week), itoa(s.MergedCount), itoa(s.MedianSeconds), itoa(s.P90Seconds),
+			})
+		}
+	case api.TimeToMergeTableReviewers:
+		records = append(records, []string{"user_id", "week", "merged_count", "median_seconds", "p90_seconds"})
+		for _, s := range stat.Reviewers {
+			records = append(records, []string{
+				s.UserId, csvTime(s.Week), itoa(s.MergedCount), itoa(s.MedianSeconds), itoa(s.P90Seconds),
+			})
+		}
+	default:
+		return nil, false
+	}
+	return records, true
+}
+
+func fairnessCSV(stat *api.FairnessStat, table api.GetStatsFairnessParamsTable) ([][]string, bool) {
+	var records [][]string
+	switch table {
+	case api.FairnessTableTeams:
+		records = append(records, []string{
+			"team_name", "member_count", "assignment_count", "mean", "min", "max", "stddev", "gini", "over_mean", "under_mean",
+		})
+		for _, s := range stat.Teams {
+			records = append(records, []string{
+				s.TeamName,
+				itoa(s.MemberCount),
+				itoa(s.AssignmentCount),
+				ftoa(s.Mean),
+				itoa(s.Min),
+				itoa(s.Max),
+				ftoa(s.Stddev),
+				ftoa(s.Gini),
+				strings.Join(s.OverMean, " "),
+				strings.Join(s.UnderMean, " "),
+			})
+		}
+	case api.FairnessTableMembers:
+		records = append(records, []string{"team_name", "user_id", "assignment_count"})
+		for _, s := range stat.Teams {
+			for _, m := range s.Members {
+				records = append(records, []string{s.TeamName, m.UserId, itoa(m.AssignmentCount)})
+			}
+		}
+	default:
+		return nil, false
+	}
+	return records, true
+}
+
+// acceptsCSV reports whether the Accept header of the request lists
+// text/csv. Other clients get JSON as before.
+func acceptsCSV(c echo.Context) bool {
+	for _, part := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == "text/csv" && params["q"] != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeCSV(c echo.Context, records [][]string) error {
+	for _, record := range records {
+		for i, cell := range record {
+			record[i] = csvCell(cell)
+		}
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().WriteHeader(http.StatusOK)
+	return csv.NewWriter(c.Response()).WriteAll(records)
+}
+
+// csvCell prefixes a quote to cells that spreadsheets would evaluate as
+// formulas, since ids in the tables are user input.
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+func ftoa(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+func csvTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
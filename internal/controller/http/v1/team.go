@@ -33,6 +33,21 @@ func (h *Handler) GetTeamGet(c echo.Context, params api.GetTeamGetParams) error
 	return c.JSON(http.StatusOK, team)
 }
 
+// GetTeamList implements api.ServerInterface.
+func (h *Handler) GetTeamList(c echo.Context, params api.GetTeamListParams) error {
+	ctx := c.Request().Context()
+
+	teams, err := h.s.ListTeams(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidCursor) {
+		return echo.ErrBadRequest
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to list teams", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, teams)
+}
+
 // PostTeamAdd implements api.ServerInterface.
 func (h *Handler) PostTeamAdd(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -42,6 +57,10 @@ func (h *Handler) PostTeamAdd(c echo.Context) error {
 		return echo.ErrBadRequest
 	}
 
+	if ok, err := h.authorizeTeam(c, team); !ok {
+		return err
+	}
+
 	addedTeam, err := h.s.AddTeam(ctx, team)
 	if errors.Is(err, postgres.ErrTeamExists) {
 		return c.JSON(http.StatusBadRequest, NewError(
@@ -58,3 +77,113 @@ func (h *Handler) PostTeamAdd(c echo.Context) error {
 		Team: *addedTeam,
 	})
 }
+
+// authorizeTeam responds with 403 unless the caller may add the team.
+// Moving an existing user needs a lead of the team the user leaves and
+// naming a lead needs an active lead, the first lead can be named while
+// there is none. When ok is false the handler returns err as is.
+func (h *Handler) authorizeTeam(c echo.Context, team api.Team) (ok bool, err error) {
+	ctx := c.Request().Context()
+	actor := actorId(c)
+
+	userIds := make([]string, 0, len(team.Members))
+	namesLead := false
+	for _, m := range team.Members {
+		userIds = append(userIds, m.UserId)
+		namesLead = namesLead || (m.Role != nil && *m.Role != api.Member)
+	}
+
+	teams, err := h.s.GetUserTeams(ctx, userIds)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get user teams", "error", err)
+		return false, echo.ErrInternalServerError
+	}
+
+	leads := map[string]bool{}
+	for _, userId := range userIds {
+		from, exists := teams[userId]
+		if !exists {
+			continue
+		}
+
+		isLead, checked := leads[from]
+		if !checked {
+			isLead, err = h.s.IsTeamLead(ctx, actor, from)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to check team lead", "user_id", actor, "error", err)
+				return false, echo.ErrInternalServerError
+			}
+			leads[from] = isLead
+		}
+		if !isLead {
+			return false, c.JSON(http.StatusForbidden, NewError(
+				api.FORBIDDEN, "Only team lead of "+from+" can move user "+userId,
+			))
+		}
+	}
+
+	if !namesLead {
+		return true, nil
+	}
+
+	isLead, err := h.s.IsLead(ctx, actor)
+	if err == nil && !isLead {
+		isLead, err = h.s.HasLead(ctx)
+		isLead = !isLead
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check lead", "user_id", actor, "error", err)
+		return false, echo.ErrInternalServerError
+	} else if !isLead {
+		return false, c.JSON(http.StatusForbidden, NewError(
+			api.FORBIDDEN, "Only team lead can assign the lead role",
+		))
+	}
+	return true, nil
+}
+
+// PostTeamDeactivateMembers implements api.ServerInterface.
+func (h *Handler) PostTeamDeactivateMembers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostTeamDeactivateMembersJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	actor := actorId(c)
+	if actor == "" {
+		return c.JSON(http.StatusForbidden, NewError(
+			api.FORBIDDEN, "Caller identity required",
+		))
+	}
+
+	isLead, err := h.s.IsTeamLead(ctx, actor, req.TeamName)
+	if errors.Is(err, postgres.ErrTeamNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Team not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to check team lead", "user_id", actor, "error", err)
+		return echo.ErrInternalServerError
+	} else if !isLead {
+		return c.JSON(http.StatusForbidden, NewError(
+			api.FORBIDDEN, "Only team lead can deactivate members",
+		))
+	}
+
+	result, err := h.s.DeactivateTeamMembers(ctx, req.TeamName, req.UserIds, actor)
+	if errors.Is(err, postgres.ErrTeamNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Team not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to deactivate team members",
+			"team_name", req.TeamName,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -46,35 +46,79 @@ func (h *Handler) PostUsersSetIsActive(c echo.Context) error {
 // GetUsersGetReview implements api.ServerInterface.
 func (h *Handler) GetUsersGetReview(c echo.Context, params api.GetUsersGetReviewParams) error {
 	ctx := c.Request().Context()
-	prs, err := h.s.GetReview(ctx, params.UserId)
+	prs, err := h.s.GetReview(ctx, params)
 	if errors.Is(err, postgres.ErrUserNotFound) {
 		return c.JSON(http.StatusNotFound, NewError(
 			api.NOTFOUND, "User not found",
 		))
+	} else if errors.Is(err, postgres.ErrInvalidCursor) || errors.Is(err, postgres.ErrInvalidListParams) {
+		return echo.ErrBadRequest
 	} else if err != nil {
 		slog.ErrorContext(ctx, "filed to get review", "error", err)
 		return echo.ErrInternalServerError
 	}
 
-	return c.JSON(http.StatusOK, &struct {
-		UserId       string                  `json:"user_id"`
-		PullRequests []*api.PullRequestShort `json:"pull_requests"`
-	}{
-		UserId:       params.UserId,
-		PullRequests: prs,
-	})
+	return c.JSON(http.StatusOK, prs)
 }
 
 // GetUsersStats implements api.ServerInterface.
-func (h *Handler) GetUsersStats(c echo.Context) error {
+func (h *Handler) GetUsersStats(c echo.Context, params api.GetUsersStatsParams) error {
 	ctx := c.Request().Context()
-	stats, err := h.s.GetUsersStats(ctx)
-	if err != nil {
+	stats, err := h.s.GetUsersStats(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidListParams) {
+		return echo.ErrBadRequest
+	} else if err != nil {
 		slog.ErrorContext(
 			ctx, "failed to get users status",
 			"error", err)
 		return echo.ErrInternalServerError
 	}
 
-	return c.JSON(http.StatusOK, stats)
+	if !acceptsCSV(c) {
+		return c.JSON(http.StatusOK, stats)
+	}
+
+	table := api.UsersStatsTableReviewers
+	if params.Table != nil {
+		table = *params.Table
+	}
+	records, ok := usersStatsCSV(stats, table)
+	if !ok {
+		return echo.ErrBadRequest
+	}
+	return writeCSV(c, records)
+}
+
+// GetUsersGet implements api.ServerInterface.
+func (h *Handler) GetUsersGet(c echo.Context, params api.GetUsersGetParams) error {
+	ctx := c.Request().Context()
+	user, err := h.s.GetUser(ctx, params.UserId)
+	if errors.Is(err, postgres.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "User not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to get user", "user_id", params.UserId, "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		User api.UserDetails `json:"user"`
+	}{
+		User: *user,
+	})
+}
+
+// GetUsersList implements api.ServerInterface.
+func (h *Handler) GetUsersList(c echo.Context, params api.GetUsersListParams) error {
+	ctx := c.Request().Context()
+	users, err := h.s.ListUsers(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidCursor) {
+		return echo.ErrBadRequest
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to list users", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, users)
 }
@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/labstack/echo/v4"
+)
+
+const minWebhookSecretLength = 16
+
+// PostWebhooksSubscribe implements api.ServerInterface.
+func (h *Handler) PostWebhooksSubscribe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostWebhooksSubscribeJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	if ok, err := h.requireLead(c, "manage webhooks"); !ok {
+		return err
+	}
+
+	if msg := validateSubscription(req); msg != "" {
+		return c.JSON(http.StatusBadRequest, NewError(
+			api.BADREQUEST, msg,
+		))
+	}
+
+	sub, err := h.s.CreateWebhookSubscription(ctx, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create webhook subscription", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusCreated, &struct {
+		Subscription api.WebhookSubscription `json:"subscription"`
+	}{
+		Subscription: *sub,
+	})
+}
+
+// GetWebhooksList implements api.ServerInterface.
+func (h *Handler) GetWebhooksList(c echo.Context) error {
+	ctx := c.Request().Context()
+	subs, err := h.s.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list webhook subscriptions", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Subscriptions []api.WebhookSubscription `json:"subscriptions"`
+	}{
+		Subscriptions: subs,
+	})
+}
+
+// PostWebhooksUnsubscribe implements api.ServerInterface.
+func (h *Handler) PostWebhooksUnsubscribe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostWebhooksUnsubscribeJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	if ok, err := h.requireLead(c, "manage webhooks"); !ok {
+		return err
+	}
+
+	sub, err := h.s.DeleteWebhookSubscription(ctx, req.SubscriptionId)
+	if errors.Is(err, postgres.ErrSubscriptionNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Subscription not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to delete webhook subscription",
+			"subscription_id", req.SubscriptionId,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Subscription api.WebhookSubscription `json:"subscription"`
+	}{
+		Subscription: *sub,
+	})
+}
+
+// GetWebhooksDeliveries implements api.ServerInterface.
+func (h *Handler) GetWebhooksDeliveries(c echo.Context, params api.GetWebhooksDeliveriesParams) error {
+	ctx := c.Request().Context()
+	deliveries, err := h.s.ListWebhookDeliveries(ctx, params)
+	if errors.Is(err, postgres.ErrInvalidCursor) || errors.Is(err, postgres.ErrInvalidListParams) {
+		return echo.ErrBadRequest
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to list webhook deliveries", "error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// PostWebhooksRedeliver implements api.ServerInterface.
+func (h *Handler) PostWebhooksRedeliver(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req api.PostWebhooksRedeliverJSONBody
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "failed to bind request", "error", err)
+		return echo.ErrBadRequest
+	}
+
+	if ok, err := h.requireLead(c, "manage webhooks"); !ok {
+		return err
+	}
+
+	delivery, err := h.s.RedeliverWebhook(ctx, req.DeliveryId)
+	if errors.Is(err, postgres.ErrDeliveryNotFound) {
+		return c.JSON(http.StatusNotFound, NewError(
+			api.NOTFOUND, "Delivery not found",
+		))
+	} else if err != nil {
+		slog.ErrorContext(ctx, "failed to redeliver webhook",
+			"delivery_id", req.DeliveryId,
+			"error", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		Delivery api.WebhookDelivery `json:"delivery"`
+	}{
+		Delivery: *delivery,
+	})
+}
+
+func validateSubscription(req api.PostWebhooksSubscribeJSONBody) string {
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "url must be an absolute http(s) URL"
+	}
+	if len(req.Secret) < minWebhookSecretLength {
+		return "secret must be at least 16 characters long"
+	}
+	if req.EventTypes != nil {
+		for _, t := range *req.EventTypes {
+			switch t {
+			case api.PrCreated, api.PrReassigned, api.PrMerged:
+			default:
+				return "unknown event type " + string(t)
+			}
+		}
+	}
+	return ""
+}
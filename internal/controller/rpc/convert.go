@@ -0,0 +1,124 @@
+package rpc
+
+import (
+	"time"
+
+	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func teamToProto(team *api.Team) *pb.Team {
+	members := make([]*pb.TeamMember, 0, len(team.Members))
+	for _, m := range team.Members {
+		member := &pb.TeamMember{
+			UserId:   m.UserId,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		}
+		if m.Role != nil {
+			member.Role = string(*m.Role)
+		}
+		members = append(members, member)
+	}
+	return &pb.Team{
+		TeamName: team.TeamName,
+		Members:  members,
+	}
+}
+
+func teamFromProto(team *pb.Team) api.Team {
+	members := make([]api.TeamMember, 0, len(team.GetMembers()))
+	for _, m := range team.GetMembers() {
+		member := api.TeamMember{
+			UserId:   m.GetUserId(),
+			Username: m.GetUsername(),
+			IsActive: m.GetIsActive(),
+		}
+		if m.GetRole() != "" {
+			role := api.TeamMemberRole(m.GetRole())
+			member.Role = &role
+		}
+		members = append(members, member)
+	}
+	return api.Team{
+		TeamName: team.GetTeamName(),
+		Members:  members,
+	}
+}
+
+func userToProto(user *api.UserDetails) *pb.User {
+	return &pb.User{
+		UserId:          user.UserId,
+		Username:        user.Username,
+		TeamName:        user.TeamName,
+		IsActive:        user.IsActive,
+		OpenReviewCount: int32(user.OpenReviewCount),
+	}
+}
+
+func pullRequestToProto(pr *api.PullRequest) *pb.PullRequest {
+	return &pb.PullRequest{
+		PullRequestId:     pr.PullRequestId,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorId,
+		Status:            string(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestamp(pr.CreatedAt),
+		MergedAt:          timestamp(pr.MergedAt),
+	}
+}
+
+func eventToProto(event api.AssignmentEvent) *pb.AssignmentEvent {
+	return &pb.AssignmentEvent{
+		EventId:       event.EventId,
+		PullRequestId: event.PullRequestId,
+		EventType:     string(event.EventType),
+		ActorId:       event.ActorId,
+		OldUserId:     event.OldUserId,
+		NewUserId:     event.NewUserId,
+		Reviewers:     event.Reviewers,
+		Reason:        event.Reason,
+		CreatedAt:     timestamppb.New(event.CreatedAt),
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// page converts a page to list parameters, zero values mean defaults.
+func page(p *pb.Page) (*int, *string) {
+	var limit *int
+	if n := int(p.GetLimit()); n != 0 {
+		limit = &n
+	}
+	return limit, optional[string](p.GetCursor())
+}
+
+func optional[T ~string](s string) *T {
+	if s == "" {
+		return nil
+	}
+	v := T(s)
+	return &v
+}
+
+func cursor(next *string) string {
+	if next == nil {
+		return ""
+	}
+	return *next
+}
@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) StreamEvents(req *pb.StreamEventsRequest, stream pb.ReviewerService_StreamEventsServer) error {
+	ctx := stream.Context()
+	filter := postgres.EventFilter{
+		UserId:   req.GetUserId(),
+		TeamName: req.GetTeamName(),
+	}
+	if err := s.s.CheckEventFilter(ctx, filter); err != nil {
+		return storageError(ctx, "failed to check event filter", err)
+	}
+
+	after := req.GetAfterEventId()
+	if req.AfterEventId == nil {
+		var err error
+		if after, err = s.s.LastEventId(ctx); err != nil {
+			return storageError(ctx, "failed to get last event id", err)
+		}
+	}
+
+	err := s.broker.Follow(ctx, s.s, filter, after, s.pollInterval, func(event api.AssignmentEvent) error {
+		return stream.Send(eventToProto(event))
+	}, func() error {
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		return storageError(ctx, "failed to stream events", err)
+	}
+
+	select {
+	case <-s.broker.Done():
+		// Clients reconnect with the last received event id.
+		return status.Error(codes.Unavailable, "server is shutting down")
+	default:
+		return nil
+	}
+}
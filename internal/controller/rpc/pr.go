@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+	"avito-trainee-task/internal/metrics"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreatePullRequest(
+	ctx context.Context,
+	req *pb.CreatePullRequestRequest,
+) (*pb.CreatePullRequestResponse, error) {
+	pr, err := s.s.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		PullRequestId:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorId:        req.GetAuthorId(),
+	}, actorId(ctx))
+	if err != nil {
+		return nil, storageError(ctx, "failed to create pull request", err)
+	}
+	metrics.ObserveAssignment(len(pr.AssignedReviewers))
+	return &pb.CreatePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *Server) MergePullRequest(
+	ctx context.Context,
+	req *pb.MergePullRequestRequest,
+) (*pb.MergePullRequestResponse, error) {
+	actor := actorId(ctx)
+	if actor != "" {
+		teamName, err := s.s.GetPullRequestTeamName(ctx, req.GetPullRequestId())
+		if err != nil {
+			return nil, storageError(ctx, "failed to get pull request team", err)
+		}
+
+		isLead, err := s.s.IsTeamLead(ctx, actor, teamName)
+		if err != nil {
+			return nil, storageError(ctx, "failed to check team lead", err)
+		} else if !isLead {
+			return nil, status.Error(codes.PermissionDenied, "only team lead can merge pull requests")
+		}
+	}
+
+	pr, err := s.s.Merge(ctx, req.GetPullRequestId(), actor)
+	if err != nil {
+		return nil, storageError(ctx, "failed to merge pull request", err)
+	}
+	return &pb.MergePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *Server) ReassignPullRequest(
+	ctx context.Context,
+	req *pb.ReassignPullRequestRequest,
+) (*pb.ReassignPullRequestResponse, error) {
+	actor := actorId(ctx)
+	if actor != "" && actor != req.GetOldUserId() {
+		teamName, err := s.s.GetPullRequestTeamName(ctx, req.GetPullRequestId())
+		if err != nil {
+			return nil, storageError(ctx, "failed to get pull request team", err)
+		}
+
+		isLead, err := s.s.IsTeamLead(ctx, actor, teamName)
+		if err != nil {
+			return nil, storageError(ctx, "failed to check team lead", err)
+		} else if !isLead {
+			return nil, status.Error(codes.PermissionDenied, "only team lead can reassign other reviewers")
+		}
+	}
+
+	pr, replacedBy, err := s.s.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: req.GetPullRequestId(),
+		OldUserId:     req.GetOldUserId(),
+		Reason:        req.Reason,
+	}, actor)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNoCandidate) {
+			metrics.ObserveReassignment(true)
+		}
+		return nil, storageError(ctx, "failed to reassign pull request", err)
+	}
+	metrics.ObserveReassignment(false)
+	return &pb.ReassignPullRequestResponse{
+		Pr:         pullRequestToProto(pr),
+		ReplacedBy: replacedBy,
+	}, nil
+}
+
+func (s *Server) GetPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.GetPullRequestResponse, error) {
+	pr, err := s.s.GetPullRequestById(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, storageError(ctx, "failed to get pull request", err)
+	}
+	return &pb.GetPullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *Server) ListPullRequests(
+	ctx context.Context,
+	req *pb.ListPullRequestsRequest,
+) (*pb.ListPullRequestsResponse, error) {
+	limit, after := page(req.GetPage())
+	prs, err := s.s.ListPullRequests(ctx, api.GetPullRequestListParams{
+		Status:      optional[api.GetPullRequestListParamsStatus](req.GetStatus()),
+		AuthorId:    req.AuthorId,
+		ReviewerId:  req.ReviewerId,
+		TeamName:    req.TeamName,
+		CreatedFrom: fromTimestamp(req.GetCreatedFrom()),
+		CreatedTo:   fromTimestamp(req.GetCreatedTo()),
+		MergedFrom:  fromTimestamp(req.GetMergedFrom()),
+		MergedTo:    fromTimestamp(req.GetMergedTo()),
+		Sort:        optional[api.GetPullRequestListParamsSort](req.GetSort()),
+		Order:       optional[api.SortOrder](req.GetOrder()),
+		Limit:       limit,
+		Cursor:      after,
+	})
+	if err != nil {
+		return nil, storageError(ctx, "failed to list pull requests", err)
+	}
+
+	resp := &pb.ListPullRequestsResponse{NextCursor: cursor(prs.NextCursor)}
+	for _, pr := range prs.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, pullRequestToProto(&pr))
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+	"avito-trainee-task/internal/events"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ActorMetadataKey carries user_id of the caller, like the X-Actor-Id
+// header of the HTTP API.
+const ActorMetadataKey = "x-actor-id"
+
+type Storage interface {
+	SetIsActive(ctx context.Context, UserId string, isActive bool) (*api.User, error)
+	GetReview(ctx context.Context, params api.GetUsersGetReviewParams) (*api.ReviewList, error)
+	GetUsersStats(ctx context.Context, params api.GetUsersStatsParams) (*api.AssignmentCountStat, error)
+	GetUser(ctx context.Context, userId string) (*api.UserDetails, error)
+	ListUsers(ctx context.Context, params api.GetUsersListParams) (*api.UserList, error)
+
+	GetTeam(ctx context.Context, teamName string) (*api.Team, error)
+	ListTeams(ctx context.Context, params api.GetTeamListParams) (*api.TeamList, error)
+	IsTeamLead(ctx context.Context, userId, teamName string) (bool, error)
+	IsLead(ctx context.Context, userId string) (bool, error)
+	HasLead(ctx context.Context) (bool, error)
+	GetUserTeams(ctx context.Context, userIds []string) (map[string]string, error)
+	DeactivateTeamMembers(ctx context.Context, teamName string, userIds []string, actorId string) (*api.DeactivationResult, error)
+	AddTeam(ctx context.Context, team api.Team) (*api.Team, error)
+
+	Merge(ctx context.Context, pullRequestId, actorId string) (*api.PullRequest, error)
+	Reassign(ctx context.Context, req api.PostPullRequestReassignJSONBody, actorId string) (*api.PullRequest, string, error)
+	CreatePullRequest(ctx context.Context, req api.PostPullRequestCreateJSONBody, actorId string) (*api.PullRequest, error)
+	GetPullRequestTeamName(ctx context.Context, prId string) (string, error)
+	GetPullRequestById(ctx context.Context, prId string) (*api.PullRequest, error)
+	ListPullRequests(ctx context.Context, params api.GetPullRequestListParams) (*api.PullRequestList, error)
+
+	CheckEventFilter(ctx context.Context, filter postgres.EventFilter) error
+	LastEventId(ctx context.Context) (int64, error)
+	ListEventsAfter(ctx context.Context, afterId int64, filter postgres.EventFilter, limit int) ([]api.AssignmentEvent, error)
+}
+
+// Server implements the gRPC ReviewerService on top of the same storage
+// as the HTTP API.
+type Server struct {
+	pb.UnimplementedReviewerServiceServer
+
+	s            Storage
+	broker       *events.Broker
+	pollInterval time.Duration
+}
+
+func NewServer(s Storage, broker *events.Broker, pollInterval time.Duration) *Server {
+	return &Server{
+		s:            s,
+		broker:       broker,
+		pollInterval: pollInterval,
+	}
+}
+
+func (s *Server) Register(srv *grpc.Server) {
+	pb.RegisterReviewerServiceServer(srv, s)
+}
+
+// errorCodes maps storage errors to status codes. The sentinel message
+// is returned to the client.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{postgres.ErrUserNotFound, codes.NotFound},
+	{postgres.ErrTeamNotFound, codes.NotFound},
+	{postgres.ErrPullRequestNotFound, codes.NotFound},
+	{postgres.ErrTeamExists, codes.AlreadyExists},
+	{postgres.ErrPullRequestExists, codes.AlreadyExists},
+	{postgres.ErrReassignMergedPullRequest, codes.FailedPrecondition},
+	{postgres.ErrUserNotAReviewer, codes.FailedPrecondition},
+	{postgres.ErrNoCandidate, codes.FailedPrecondition},
+	{postgres.ErrInvalidCursor, codes.InvalidArgument},
+	{postgres.ErrInvalidListParams, codes.InvalidArgument},
+}
+
+// storageError converts err to a status. Unknown errors are logged and
+// reported as Internal without details.
+func storageError(ctx context.Context, msg string, err error) error {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.err.Error())
+		}
+	}
+	slog.ErrorContext(ctx, msg, "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func actorId(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, ActorMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+
+	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.AddTeamResponse, error) {
+	if req.GetTeam().GetTeamName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team_name is required")
+	}
+	for _, m := range req.GetTeam().GetMembers() {
+		switch api.TeamMemberRole(m.GetRole()) {
+		case "", api.Lead, api.Member:
+		default:
+			return nil, status.Error(codes.InvalidArgument, "unknown role "+m.GetRole())
+		}
+	}
+
+	team := teamFromProto(req.GetTeam())
+	if err := s.authorizeTeam(ctx, team); err != nil {
+		return nil, err
+	}
+
+	added, err := s.s.AddTeam(ctx, team)
+	if err != nil {
+		return nil, storageError(ctx, "failed to add team", err)
+	}
+	return &pb.AddTeamResponse{Team: teamToProto(added)}, nil
+}
+
+// authorizeTeam mirrors the HTTP check: moving an existing user needs a
+// lead of the team the user leaves and naming a lead needs an active lead,
+// unless there is none yet.
+func (s *Server) authorizeTeam(ctx context.Context, team api.Team) error {
+	actor := actorId(ctx)
+
+	userIds := make([]string, 0, len(team.Members))
+	namesLead := false
+	for _, m := range team.Members {
+		userIds = append(userIds, m.UserId)
+		namesLead = namesLead || (m.Role != nil && *m.Role != api.Member)
+	}
+
+	teams, err := s.s.GetUserTeams(ctx, userIds)
+	if err != nil {
+		return storageError(ctx, "failed to get user teams", err)
+	}
+
+	leads := map[string]bool{}
+	for _, userId := range userIds {
+		from, exists := teams[userId]
+		if !exists {
+			continue
+		}
+
+		isLead, checked := leads[from]
+		if !checked {
+			isLead, err = s.s.IsTeamLead(ctx, actor, from)
+			if err != nil {
+				return storageError(ctx, "failed to check team lead", err)
+			}
+			leads[from] = isLead
+		}
+		if !isLead {
+			return status.Error(codes.PermissionDenied, "only team lead of "+from+" can move user "+userId)
+		}
+	}
+
+	if !namesLead {
+		return nil
+	}
+
+	isLead, err := s.s.IsLead(ctx, actor)
+	if err == nil && !isLead {
+		isLead, err = s.s.HasLead(ctx)
+		isLead = !isLead
+	}
+	if err != nil {
+		return storageError(ctx, "failed to check lead", err)
+	} else if !isLead {
+		return status.Error(codes.PermissionDenied, "only team lead can assign the lead role")
+	}
+	return nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	team, err := s.s.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, storageError(ctx, "failed to get team", err)
+	}
+	return &pb.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+	limit, after := page(req.GetPage())
+	teams, err := s.s.ListTeams(ctx, api.GetTeamListParams{Limit: limit, Cursor: after})
+	if err != nil {
+		return nil, storageError(ctx, "failed to list teams", err)
+	}
+
+	resp := &pb.ListTeamsResponse{NextCursor: cursor(teams.NextCursor)}
+	for _, t := range teams.Teams {
+		resp.Teams = append(resp.Teams, &pb.TeamSummary{
+			TeamName:             t.TeamName,
+			MemberCount:          int32(t.MemberCount),
+			ActiveMemberCount:    int32(t.ActiveMemberCount),
+			OpenPullRequestCount: int32(t.OpenPullRequestCount),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) DeactivateTeamMembers(
+	ctx context.Context,
+	req *pb.DeactivateTeamMembersRequest,
+) (*pb.DeactivateTeamMembersResponse, error) {
+	actor := actorId(ctx)
+	if actor == "" {
+		return nil, status.Error(codes.PermissionDenied, "caller identity required")
+	}
+
+	isLead, err := s.s.IsTeamLead(ctx, actor, req.GetTeamName())
+	if err != nil {
+		return nil, storageError(ctx, "failed to check team lead", err)
+	} else if !isLead {
+		return nil, status.Error(codes.PermissionDenied, "only team lead can deactivate members")
+	}
+
+	result, err := s.s.DeactivateTeamMembers(ctx, req.GetTeamName(), req.GetUserIds(), actor)
+	if err != nil {
+		return nil, storageError(ctx, "failed to deactivate team members", err)
+	}
+
+	resp := &pb.DeactivateTeamMembersResponse{
+		TeamName:           result.TeamName,
+		DeactivatedUserIds: result.DeactivatedUserIds,
+	}
+	for _, r := range result.Reassignments {
+		reassignment := &pb.Reassignment{
+			PullRequestId: r.PullRequestId,
+			OldUserId:     r.OldUserId,
+		}
+		if r.ReplacedBy != nil {
+			reassignment.ReplacedBy = *r.ReplacedBy
+		}
+		resp.Reassignments = append(resp.Reassignments, reassignment)
+	}
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+
+	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+)
+
+func (s *Server) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.SetIsActiveResponse, error) {
+	user, err := s.s.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, storageError(ctx, "failed to set isActive", err)
+	}
+	return &pb.SetIsActiveResponse{User: &pb.User{
+		UserId:   user.UserId,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}}, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	user, err := s.s.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, storageError(ctx, "failed to get user", err)
+	}
+	return &pb.GetUserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	limit, after := page(req.GetPage())
+	users, err := s.s.ListUsers(ctx, api.GetUsersListParams{
+		TeamName:       req.TeamName,
+		IsActive:       req.IsActive,
+		UsernamePrefix: req.UsernamePrefix,
+		Limit:          limit,
+		Cursor:         after,
+	})
+	if err != nil {
+		return nil, storageError(ctx, "failed to list users", err)
+	}
+
+	resp := &pb.ListUsersResponse{NextCursor: cursor(users.NextCursor)}
+	for _, u := range users.Users {
+		resp.Users = append(resp.Users, userToProto(&u))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	limit, after := page(req.GetPage())
+	review, err := s.s.GetReview(ctx, api.GetUsersGetReviewParams{
+		UserId: req.GetUserId(),
+		Status: optional[api.GetUsersGetReviewParamsStatus](req.GetStatus()),
+		Order:  optional[api.SortOrder](req.GetOrder()),
+		Limit:  limit,
+		Cursor: after,
+	})
+	if err != nil {
+		return nil, storageError(ctx, "failed to get review", err)
+	}
+
+	resp := &pb.GetReviewResponse{
+		UserId:     review.UserId,
+		Total:      int32(review.Total),
+		NextCursor: cursor(review.NextCursor),
+	}
+	for _, pr := range review.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, &pb.PullRequestShort{
+			PullRequestId:   pr.PullRequestId,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorId,
+			Status:          string(pr.Status),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	stats, err := s.s.GetUsersStats(ctx, api.GetUsersStatsParams{
+		From: fromTimestamp(req.GetFrom()),
+		To:   fromTimestamp(req.GetTo()),
+	})
+	if err != nil {
+		return nil, storageError(ctx, "failed to get users stats", err)
+	}
+
+	resp := &pb.GetStatsResponse{}
+	for _, stat := range stats.Stats {
+		resp.Stats = append(resp.Stats, &pb.AssignmentCount{
+			UserId:          stat.UserId,
+			AssignmentCount: int32(stat.AssignmentCount),
+			OpenCount:       int32(stat.OpenCount),
+			MergedCount:     int32(stat.MergedCount),
+		})
+	}
+	for _, team := range stats.Teams {
+		resp.Teams = append(resp.Teams, &pb.TeamStat{
+			TeamName:          team.TeamName,
+			PullRequestCount:  int32(team.PullRequestCount),
+			OpenCount:         int32(team.OpenCount),
+			MergedCount:       int32(team.MergedCount),
+			ReassignmentCount: int32(team.ReassignmentCount),
+		})
+	}
+	for _, author := range stats.Authors {
+		resp.Authors = append(resp.Authors, &pb.AuthorStat{
+			UserId:           author.UserId,
+			PullRequestCount: int32(author.PullRequestCount),
+			OpenCount:        int32(author.OpenCount),
+			MergedCount:      int32(author.MergedCount),
+		})
+	}
+	for _, pr := range stats.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, &pb.PullRequestStat{
+			PullRequestId:     pr.PullRequestId,
+			AuthorId:          pr.AuthorId,
+			Status:            string(pr.Status),
+			ReviewerCount:     int32(pr.ReviewerCount),
+			ReassignmentCount: int32(pr.ReassignmentCount),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/worker"
+)
+
+const pageSize = 100
+
+type Store interface {
+	ListenEvents(ctx context.Context, notify func(eventId int64)) error
+}
+
+type Reader interface {
+	ListEventsAfter(ctx context.Context, afterId int64, filter postgres.EventFilter, limit int) ([]api.AssignmentEvent, error)
+}
+
+type Config struct {
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Broker listens for committed assignment events on a single database
+// connection and wakes up the subscribed streams. Streams read the events
+// themselves, so a wake-up carries no data and may be coalesced.
+type Broker struct {
+	store Store
+	cfg   Config
+
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	done        chan struct{}
+}
+
+func NewBroker(store Store, cfg Config) *Broker {
+	return &Broker{
+		store:       store,
+		cfg:         cfg,
+		subscribers: map[chan struct{}]struct{}{},
+		done:        make(chan struct{}),
+	}
+}
+
+// Run listens until ctx is done, reconnecting with backoff when the
+// connection fails. Subscribers are woken up after every reconnect to
+// catch up on events committed while the broker was not listening.
+func (b *Broker) Run(ctx context.Context) {
+	defer close(b.done)
+
+	for attempt := 1; ; attempt++ {
+		err := b.store.ListenEvents(ctx, func(int64) {
+			attempt = 0
+			b.broadcast()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "event listener stopped", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(worker.Backoff(attempt, b.cfg.MinBackoff, b.cfg.MaxBackoff)):
+		}
+		b.broadcast()
+	}
+}
+
+// Done is closed when the broker stops, so that streams end before
+// the server shuts down.
+func (b *Broker) Done() <-chan struct{} {
+	return b.done
+}
+
+// Subscribe returns a channel receiving a value when new events may be
+// available and a function cancelling the subscription.
+func (b *Broker) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+}
+
+func (b *Broker) broadcast() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Follow calls send for every event after the id in stream order and
+// then for new events as they are committed, until ctx is done or the
+// broker stops. idle is called every interval without new events; the
+// events are also re-read then, in case a wake-up was missed.
+func (b *Broker) Follow(
+	ctx context.Context,
+	r Reader,
+	filter postgres.EventFilter,
+	after int64,
+	interval time.Duration,
+	send func(api.AssignmentEvent) error,
+	idle func() error,
+) error {
+	// Events committed before the subscription are read by the first
+	// query, so none are lost in between.
+	wake, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			events, err := r.ListEventsAfter(ctx, after, filter, pageSize)
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				if err := send(event); err != nil {
+					return err
+				}
+				after = event.EventId
+			}
+			if len(events) < pageSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-b.done:
+			return nil
+		case <-wake:
+		case <-ticker.C:
+			if err := idle(); err != nil {
+				return err
+			}
+		}
+	}
+}
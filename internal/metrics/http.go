@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	// Buckets include the 300ms latency objective of the service.
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .2, .3, .5, 1, 2.5, 5},
+	}, []string{"method", "route"})
+)
+
+// RegisterRoutes instruments every route of e and serves the registry
+// at /metrics.
+func RegisterRoutes(e *echo.Echo) {
+	e.Use(Middleware)
+	e.GET("/metrics", echo.WrapHandler(Handler()))
+}
+
+// Middleware records requests by the route template rather than the path so
+// that identifiers in paths do not grow the number of series.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request().Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(status(c, err))).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// status returns the code the error handler is going to respond with
+// when the handler failed before writing the response.
+func status(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
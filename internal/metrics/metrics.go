@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+// Registry holds the service metrics together with the Go runtime
+// and process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	reviewerAssignments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_request_reviewers_assigned_total",
+		Help:      "Pull requests created by the number of reviewers assigned on creation.",
+	}, []string{"reviewers"})
+
+	reassignments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reassignments_total",
+		Help:      "Reviewer reassignment attempts by result: reassigned or no_candidate.",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		reviewerAssignments,
+		reassignments,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format. A failing
+// collector drops its metrics instead of failing the whole scrape.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// ObserveAssignment counts a created pull request by the number of
+// reviewers it received.
+func ObserveAssignment(reviewers int) {
+	reviewerAssignments.WithLabelValues(strconv.Itoa(reviewers)).Inc()
+}
+
+// ObserveReassignment counts a reassignment attempt, noCandidate is set when
+// the team had no active member to replace the reviewer.
+func ObserveReassignment(noCandidate bool) {
+	result := "reassigned"
+	if noCandidate {
+		result = "no_candidate"
+	}
+	reassignments.WithLabelValues(result).Inc()
+}
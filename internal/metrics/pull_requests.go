@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const queryTimeout = 5 * time.Second
+
+type Store interface {
+	CountOpenPullRequests(ctx context.Context) (map[string]int, error)
+}
+
+// PullRequestCollector queries the number of open pull requests per team
+// at scrape time.
+type PullRequestCollector struct {
+	s Store
+
+	open *prometheus.Desc
+}
+
+func NewPullRequestCollector(s Store) *PullRequestCollector {
+	return &PullRequestCollector{
+		s: s,
+		open: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_pull_requests"),
+			"Open pull requests by the team of the author.",
+			[]string{"team"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PullRequestCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.open
+}
+
+// Collect implements prometheus.Collector.
+func (c *PullRequestCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	counts, err := c.s.CountOpenPullRequests(ctx)
+	if err != nil {
+		slog.Error("failed to count open pull requests", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.open, err)
+		return
+	}
+	for team, n := range counts {
+		ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(n), team)
+	}
+}
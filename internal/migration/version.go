@@ -0,0 +1,28 @@
+package migration
+
+import (
+	"io/fs"
+
+	"avito-trainee-task/migrations"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// ExpectedVersion returns the latest version among the migrations
+// embedded in the binary.
+func ExpectedVersion() (uint, error) {
+	names, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint
+	for _, name := range names {
+		m, err := source.Parse(name)
+		if err != nil {
+			return 0, err
+		}
+		version = max(version, m.Version)
+	}
+	return version, nil
+}
@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/worker"
+)
+
+type Store interface {
+	ClaimNotifications(
+		ctx context.Context,
+		transport postgres.NotificationTransport,
+		limit int,
+		lease time.Duration,
+	) ([]postgres.PendingNotification, error)
+	MarkNotificationSent(ctx context.Context, notificationId int64) error
+	MarkNotificationFailed(ctx context.Context, notificationId int64, lastError string, retryIn *time.Duration) error
+}
+
+// ChatMessage is the body of a Slack or Mattermost incoming webhook.
+// Channel overrides the default channel of the webhook.
+type ChatMessage struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// ChatNotifier posts queued notifications to a chat incoming webhook.
+// Notifications are sent after the assignment is committed, so failures
+// only affect the notification itself.
+type ChatNotifier struct {
+	store     Store
+	client    *http.Client
+	url       string
+	templates *Templates
+	cfg       worker.Config
+}
+
+func NewChatNotifier(store Store, webhookURL string, templates *Templates, cfg worker.Config) *ChatNotifier {
+	return &ChatNotifier{
+		store:     store,
+		client:    &http.Client{Timeout: cfg.Timeout},
+		url:       webhookURL,
+		templates: templates,
+		cfg:       cfg,
+	}
+}
+
+// Run sends due notifications every interval until ctx is done.
+func (n *ChatNotifier) Run(ctx context.Context) {
+	worker.Run(ctx, n.cfg.Interval, "failed to send chat notifications", func(ctx context.Context) error {
+		_, err := n.NotifyOnce(ctx)
+		return err
+	})
+}
+
+// NotifyOnce sends one batch of due notifications and returns its size.
+func (n *ChatNotifier) NotifyOnce(ctx context.Context) (int, error) {
+	notifications, err := n.store.ClaimNotifications(ctx, postgres.ChatTransport, n.cfg.BatchSize, 2*n.cfg.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, notification := range notifications {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.notify(ctx, notification); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "failed to record chat notification",
+					"notification_id", notification.NotificationId,
+					"error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return len(notifications), nil
+}
+
+func (n *ChatNotifier) notify(ctx context.Context, notification postgres.PendingNotification) error {
+	retryable, err := n.send(ctx, notification)
+	if err == nil {
+		return n.store.MarkNotificationSent(ctx, notification.NotificationId)
+	}
+
+	retryIn := n.cfg.RetryIn(notification.Attempts+1, retryable)
+	return n.store.MarkNotificationFailed(ctx, notification.NotificationId, worker.ErrorMessage(err), retryIn)
+}
+
+func (n *ChatNotifier) send(ctx context.Context, notification postgres.PendingNotification) (bool, error) {
+	data := messageData(notification)
+	text, err := n.templates.Render(data.Kind, data)
+	if err != nil {
+		return false, err
+	}
+
+	msg := ChatMessage{Text: text}
+	if notification.Contact.ChatChannel != nil {
+		msg.Channel = *notification.Contact.ChatChannel
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("notify.send failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return false, nil
+}
+
+func messageData(notification postgres.PendingNotification) MessageData {
+	event, contact := notification.Event, notification.Contact
+	data := MessageData{
+		Kind:            notification.Kind,
+		Mention:         contact.UserId,
+		UserId:          contact.UserId,
+		PullRequestId:   event.PullRequestId,
+		PullRequestName: notification.PullRequestName,
+		EventType:       string(event.EventType),
+		ActorId:         deref(event.ActorId),
+		OldUserId:       deref(event.OldUserId),
+		NewUserId:       deref(event.NewUserId),
+		Reviewers:       event.Reviewers,
+		Reason:          deref(event.Reason),
+	}
+	if contact.ChatHandle != nil {
+		data.Mention = "@" + *contact.ChatHandle
+	}
+	return data
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,392 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/worker"
+)
+
+//go:embed templates
+var defaultEmailTemplates embed.FS
+
+const (
+	notificationTemplate = "notification"
+	reminderTemplate     = "reminder"
+)
+
+// errNoEmail is recorded for notifications queued before the recipient
+// removed the email address.
+var errNoEmail = errors.New("contact has no email address")
+
+type EmailStore interface {
+	Store
+	ClaimEmailReminders(ctx context.Context, period, olderThan time.Duration, limit int) ([]postgres.Reminder, error)
+}
+
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+
+	worker.Config
+
+	ReminderInterval time.Duration
+	ReminderAfter    time.Duration
+}
+
+// NotificationEmail is passed to notification templates. Items are the
+// notifications of the recipient collected since the previous batch.
+type NotificationEmail struct {
+	UserId string
+	Items  []MessageData
+}
+
+// ReminderEmail is passed to reminder templates.
+type ReminderEmail struct {
+	UserId       string
+	PullRequests []api.PullRequest
+}
+
+// EmailTemplates renders the subject and the plain text body from a
+// text/template and the HTML body from an html/template. The subject is
+// the "subject" template defined in the text one.
+type EmailTemplates struct {
+	text map[string]*template.Template
+	html map[string]*htmltemplate.Template
+}
+
+// LoadEmailTemplates reads notification and reminder templates from
+// <name>.txt and <name>.html files of the directory. Missing files and
+// an empty dir fall back to the built-in templates.
+func LoadEmailTemplates(dir string) (*EmailTemplates, error) {
+	builtin, err := fs.Sub(defaultEmailTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	read := func(name string) ([]byte, error) {
+		if dir != "" {
+			data, err := os.ReadFile(dir + string(os.PathSeparator) + name)
+			if err == nil || !errors.Is(err, fs.ErrNotExist) {
+				return data, err
+			}
+		}
+		return fs.ReadFile(builtin, name)
+	}
+
+	templates := &EmailTemplates{
+		text: map[string]*template.Template{},
+		html: map[string]*htmltemplate.Template{},
+	}
+	for _, name := range []string{notificationTemplate, reminderTemplate} {
+		src, err := read(name + ".txt")
+		if err != nil {
+			return nil, fmt.Errorf("notify.LoadEmailTemplates failed to read %v.txt: %w", name, err)
+		}
+		text, err := template.New(name).Parse(string(src))
+		if err != nil {
+			return nil, fmt.Errorf("notify.LoadEmailTemplates failed to parse %v.txt: %w", name, err)
+		}
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("notify.LoadEmailTemplates: %v.txt does not define subject", name)
+		}
+
+		src, err = read(name + ".html")
+		if err != nil {
+			return nil, fmt.Errorf("notify.LoadEmailTemplates failed to read %v.html: %w", name, err)
+		}
+		html, err := htmltemplate.New(name).Parse(string(src))
+		if err != nil {
+			return nil, fmt.Errorf("notify.LoadEmailTemplates failed to parse %v.html: %w", name, err)
+		}
+
+		templates.text[name] = text
+		templates.html[name] = html
+	}
+	return templates, nil
+}
+
+type email struct {
+	subject string
+	text    string
+	html    string
+}
+
+func (t *EmailTemplates) render(name string, data any) (email, error) {
+	var subject, text, html bytes.Buffer
+	if err := t.text[name].ExecuteTemplate(&subject, "subject", data); err != nil {
+		return email{}, fmt.Errorf("notify.render failed to execute %v subject: %w", name, err)
+	}
+	if err := t.text[name].Execute(&text, data); err != nil {
+		return email{}, fmt.Errorf("notify.render failed to execute %v.txt: %w", name, err)
+	}
+	if err := t.html[name].Execute(&html, data); err != nil {
+		return email{}, fmt.Errorf("notify.render failed to execute %v.html: %w", name, err)
+	}
+	return email{subject: subject.String(), text: text.String(), html: html.String()}, nil
+}
+
+// EmailNotifier sends queued notifications over SMTP. Notifications of a
+// recipient claimed together are batched into one email, reminders about
+// open reviews are sent once per reminder interval.
+type EmailNotifier struct {
+	store     EmailStore
+	templates *EmailTemplates
+	cfg       EmailConfig
+}
+
+func NewEmailNotifier(store EmailStore, templates *EmailTemplates, cfg EmailConfig) *EmailNotifier {
+	return &EmailNotifier{
+		store:     store,
+		templates: templates,
+		cfg:       cfg,
+	}
+}
+
+// Run sends due notifications every interval and reminders every
+// reminder interval until ctx is done.
+func (n *EmailNotifier) Run(ctx context.Context) {
+	worker.Run(ctx, n.cfg.Interval, "failed to send emails", func(ctx context.Context) error {
+		_, notifyErr := n.NotifyOnce(ctx)
+		_, remindErr := n.RemindOnce(ctx)
+		return errors.Join(notifyErr, remindErr)
+	})
+}
+
+// NotifyOnce sends one batch of due notifications and returns the number
+// of emails sent. Emails are sent concurrently, so the batch completes
+// within the claim lease like a single send.
+func (n *EmailNotifier) NotifyOnce(ctx context.Context) (int, error) {
+	notifications, err := n.store.ClaimNotifications(ctx, postgres.EmailTransport, n.cfg.BatchSize, 2*n.cfg.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var order []string
+	batches := map[string][]postgres.PendingNotification{}
+	for _, notification := range notifications {
+		userId := notification.Contact.UserId
+		if _, ok := batches[userId]; !ok {
+			order = append(order, userId)
+		}
+		batches[userId] = append(batches[userId], notification)
+	}
+
+	var sent atomic.Int32
+	var wg sync.WaitGroup
+	for _, userId := range order {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.notify(ctx, batches[userId]); err != nil {
+				if ctx.Err() == nil {
+					slog.ErrorContext(ctx, "failed to record email notification", "user_id", userId, "error", err)
+				}
+				return
+			}
+			sent.Add(1)
+		}()
+	}
+	wg.Wait()
+
+	return int(sent.Load()), ctx.Err()
+}
+
+func (n *EmailNotifier) notify(ctx context.Context, batch []postgres.PendingNotification) error {
+	contact := batch[0].Contact
+	data := NotificationEmail{UserId: contact.UserId}
+	for _, notification := range batch {
+		data.Items = append(data.Items, messageData(notification))
+	}
+
+	retryable := false
+	msg, err := n.templates.render(notificationTemplate, data)
+	if err == nil && contact.Email == nil {
+		err = errNoEmail
+	} else if err == nil {
+		retryable, err = n.send(ctx, string(*contact.Email), msg)
+	}
+
+	for _, notification := range batch {
+		var markErr error
+		if err == nil {
+			markErr = n.store.MarkNotificationSent(ctx, notification.NotificationId)
+		} else {
+			markErr = n.markFailed(ctx, notification, err, retryable)
+		}
+		if markErr != nil {
+			return markErr
+		}
+	}
+	return nil
+}
+
+func (n *EmailNotifier) markFailed(
+	ctx context.Context,
+	notification postgres.PendingNotification,
+	err error,
+	retryable bool,
+) error {
+	retryIn := n.cfg.RetryIn(notification.Attempts+1, retryable)
+	return n.store.MarkNotificationFailed(ctx, notification.NotificationId, worker.ErrorMessage(err), retryIn)
+}
+
+// RemindOnce sends reminders to users due for one and returns the number
+// of emails sent. Reminders are not retried.
+func (n *EmailNotifier) RemindOnce(ctx context.Context) (int, error) {
+	reminders, err := n.store.ClaimEmailReminders(ctx, n.cfg.ReminderInterval, n.cfg.ReminderAfter, n.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		msg, err := n.templates.render(reminderTemplate, ReminderEmail{
+			UserId:       reminder.UserId,
+			PullRequests: reminder.PullRequests,
+		})
+		if err == nil {
+			_, err = n.send(ctx, reminder.Email, msg)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+			slog.ErrorContext(ctx, "failed to send email reminder", "user_id", reminder.UserId, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// send delivers the email and reports whether a failure is worth
+// retrying: SMTP replies in the 5xx range are permanent.
+func (n *EmailNotifier) send(ctx context.Context, to string, msg email) (bool, error) {
+	body, err := n.compose(to, msg)
+	if err != nil {
+		return false, err
+	}
+
+	err = n.deliver(ctx, to, body)
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		return false, err
+	}
+	return true, err
+}
+
+func (n *EmailNotifier) deliver(ctx context.Context, to string, body []byte) error {
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+	dialer := net.Dialer{Timeout: n.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(n.cfg.Timeout)); err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// compose builds a multipart/alternative message with the plain text
+// and the HTML versions of the email.
+func (n *EmailNotifier) compose(to string, msg email) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", msg.text},
+		{"text/html; charset=utf-8", msg.html},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	b.Write(body.Bytes())
+	return b.Bytes(), nil
+}
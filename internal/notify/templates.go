@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"avito-trainee-task/internal/api"
+
+	"gopkg.in/yaml.v3"
+)
+
+var defaultTemplates = map[api.NotificationKind]string{
+	api.KindAssigned: `{{.Mention}}, you were assigned to review *{{.PullRequestName}}* ({{.PullRequestId}})` +
+		`{{if .ActorId}} by {{.ActorId}}{{end}}{{if .Reason}}: {{.Reason}}{{end}}`,
+	api.KindUnassigned: `{{.Mention}}, you were replaced as a reviewer of *{{.PullRequestName}}* ({{.PullRequestId}})` +
+		`{{if .NewUserId}} by {{.NewUserId}}{{end}}{{if .Reason}}: {{.Reason}}{{end}}`,
+	api.KindMerged: `{{.Mention}}, *{{.PullRequestName}}* ({{.PullRequestId}}) you review was merged` +
+		`{{if .ActorId}} by {{.ActorId}}{{end}}`,
+}
+
+// MessageData is passed to message templates.
+type MessageData struct {
+	Kind api.NotificationKind
+
+	// Mention is @handle of the recipient or their user_id
+	// when the handle is unknown.
+	Mention         string
+	UserId          string
+	PullRequestId   string
+	PullRequestName string
+	EventType       string
+	ActorId         string
+	OldUserId       string
+	NewUserId       string
+	Reviewers       []string
+	Reason          string
+}
+
+// Templates renders chat messages of each kind.
+type Templates struct {
+	t map[api.NotificationKind]*template.Template
+}
+
+// LoadTemplates parses the YAML file mapping message kinds to
+// text/template sources. Kinds missing from the file and an empty
+// path fall back to the defaults.
+func LoadTemplates(path string) (*Templates, error) {
+	sources := map[api.NotificationKind]string{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("notify.LoadTemplates failed to read file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &sources); err != nil {
+			return nil, fmt.Errorf("notify.LoadTemplates failed to parse file: %w", err)
+		}
+	}
+	return ParseTemplates(sources)
+}
+
+func ParseTemplates(sources map[api.NotificationKind]string) (*Templates, error) {
+	templates := &Templates{t: map[api.NotificationKind]*template.Template{}}
+	for kind, def := range defaultTemplates {
+		src, ok := sources[kind]
+		if !ok {
+			src = def
+		}
+		t, err := template.New(string(kind)).Option("missingkey=error").Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("notify.ParseTemplates failed to parse %v template: %w", kind, err)
+		}
+		templates.t[kind] = t
+	}
+	for kind := range sources {
+		if _, ok := defaultTemplates[kind]; !ok {
+			return nil, fmt.Errorf("notify.ParseTemplates: unknown message kind %q", kind)
+		}
+	}
+	return templates, nil
+}
+
+func (t *Templates) Render(kind api.NotificationKind, data MessageData) (string, error) {
+	tmpl, ok := t.t[kind]
+	if !ok {
+		return "", fmt.Errorf("notify.Render: unknown message kind %q", kind)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("notify.Render failed to execute %v template: %w", kind, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,173 @@
+package orgchart
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"avito-trainee-task/internal/api"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported import format")
+	ErrInvalidChart      = errors.New("invalid org chart")
+)
+
+var csvColumns = []string{"team_name", "user_id", "username", "is_active", "role"}
+
+type yamlChart struct {
+	Teams []struct {
+		TeamName string `yaml:"team_name"`
+		Members  []struct {
+			UserId   string  `yaml:"user_id"`
+			Username string  `yaml:"username"`
+			IsActive *bool   `yaml:"is_active"`
+			Role     *string `yaml:"role"`
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+// Parse reads an org chart in the format given by the request content type.
+func Parse(contentType string, r io.Reader) ([]api.Team, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return ParseCSV(r)
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return ParseYAML(r)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func ParseCSV(r io.Reader) ([]api.Team, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidChart, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.TrimSpace(column)] = i
+	}
+	for _, column := range csvColumns[:3] {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidChart, column)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := index[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var teams []api.Team
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidChart, err)
+		}
+
+		member := api.TeamMember{
+			UserId:   field(record, "user_id"),
+			Username: field(record, "username"),
+			IsActive: true,
+		}
+		if v := field(record, "is_active"); v != "" {
+			if member.IsActive, err = strconv.ParseBool(v); err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid is_active %q", ErrInvalidChart, line, v)
+			}
+		}
+		if v := field(record, "role"); v != "" {
+			role := api.TeamMemberRole(v)
+			member.Role = &role
+		}
+
+		teams = addMember(teams, field(record, "team_name"), member)
+	}
+
+	return teams, validate(teams)
+}
+
+func ParseYAML(r io.Reader) ([]api.Team, error) {
+	var chart yamlChart
+	if err := yaml.NewDecoder(r).Decode(&chart); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChart, err)
+	}
+
+	var teams []api.Team
+	for _, t := range chart.Teams {
+		for _, m := range t.Members {
+			member := api.TeamMember{
+				UserId:   m.UserId,
+				Username: m.Username,
+				IsActive: m.IsActive == nil || *m.IsActive,
+			}
+			if m.Role != nil {
+				role := api.TeamMemberRole(*m.Role)
+				member.Role = &role
+			}
+			teams = addMember(teams, t.TeamName, member)
+		}
+	}
+
+	return teams, validate(teams)
+}
+
+func addMember(teams []api.Team, teamName string, member api.TeamMember) []api.Team {
+	i := slices.IndexFunc(teams, func(t api.Team) bool {
+		return t.TeamName == teamName
+	})
+	if i < 0 {
+		return append(teams, api.Team{
+			TeamName: teamName,
+			Members:  []api.TeamMember{member},
+		})
+	}
+	teams[i].Members = append(teams[i].Members, member)
+	return teams
+}
+
+func validate(teams []api.Team) error {
+	if len(teams) == 0 {
+		return fmt.Errorf("%w: no members", ErrInvalidChart)
+	}
+
+	seen := make(map[string]struct{})
+	for _, t := range teams {
+		if t.TeamName == "" {
+			return fmt.Errorf("%w: empty team_name", ErrInvalidChart)
+		}
+		for _, m := range t.Members {
+			switch {
+			case m.UserId == "":
+				return fmt.Errorf("%w: empty user_id in team %s", ErrInvalidChart, t.TeamName)
+			case m.Username == "":
+				return fmt.Errorf("%w: empty username for user %s", ErrInvalidChart, m.UserId)
+			case m.Role != nil && *m.Role != api.Lead && *m.Role != api.Member:
+				return fmt.Errorf("%w: invalid role %q for user %s", ErrInvalidChart, *m.Role, m.UserId)
+			}
+			if _, ok := seen[m.UserId]; ok {
+				return fmt.Errorf("%w: duplicate user_id %s", ErrInvalidChart, m.UserId)
+			}
+			seen[m.UserId] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package snapshot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"avito-trainee-task/internal/worker"
+)
+
+// maxBackfillDays limits how many missed days a run stores. Days without
+// pull requests have no rows and would be retried on every run otherwise.
+const maxBackfillDays = 31
+
+type Store interface {
+	SaveStatsSnapshot(ctx context.Context, day time.Time) (int64, error)
+	LastStatsSnapshotDate(ctx context.Context) (*time.Time, error)
+}
+
+type Config struct {
+	Interval time.Duration
+}
+
+// Snapshotter stores daily statistics so that trends stay available after
+// pull requests are deleted or teams change.
+type Snapshotter struct {
+	store Store
+	cfg   Config
+}
+
+func NewSnapshotter(store Store, cfg Config) *Snapshotter {
+	return &Snapshotter{
+		store: store,
+		cfg:   cfg,
+	}
+}
+
+// Run stores the snapshots of past UTC days every interval until ctx is
+// done. Stored days are skipped, so restarts and several replicas write
+// each day once.
+func (s *Snapshotter) Run(ctx context.Context) {
+	worker.Run(ctx, s.cfg.Interval, "failed to save stats snapshot", func(ctx context.Context) error {
+		_, err := s.SnapshotOnce(ctx, time.Now())
+		return err
+	})
+}
+
+// SnapshotOnce stores the snapshots of the UTC days after the last stored
+// one up to the day before now, at most maxBackfillDays of them, and
+// returns the number of rows added.
+func (s *Snapshotter) SnapshotOnce(ctx context.Context, now time.Time) (int64, error) {
+	yesterday := now.UTC().AddDate(0, 0, -1)
+	day := yesterday
+
+	last, err := s.store.LastStatsSnapshotDate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if last != nil {
+		day = last.UTC().AddDate(0, 0, 1)
+		if earliest := yesterday.AddDate(0, 0, 1-maxBackfillDays); day.Before(earliest) {
+			day = earliest
+		}
+	}
+
+	var total int64
+	for ; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		n, err := s.store.SaveStatsSnapshot(ctx, day)
+		if err != nil {
+			return total, err
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "saved stats snapshot", "day", day.Format(time.DateOnly), "rows", n)
+		}
+		total += n
+	}
+	return total, nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"avito-trainee-task/internal/api"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+
+	cursorSeparator = "\x00"
+)
+
+func pageLimit(limit *int) int {
+	switch {
+	case limit == nil:
+		return defaultPageLimit
+	case *limit < 1:
+		return 1
+	case *limit > maxPageLimit:
+		return maxPageLimit
+	}
+	return *limit
+}
+
+func encodeCursor(keys ...string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(keys, cursorSeparator)))
+}
+
+func decodeCursor(cursor string, n int) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	keys := strings.Split(string(raw), cursorSeparator)
+	if len(keys) != n {
+		return nil, ErrInvalidCursor
+	}
+	return keys, nil
+}
+
+// nextPage trims the extra row fetched past the limit and builds
+// the cursor pointing after the last returned item.
+func nextPage[T any](items []T, limit int, keys func(T) []string) ([]T, *string) {
+	if len(items) <= limit {
+		return items, nil
+	}
+
+	items = items[:limit]
+	cursor := encodeCursor(keys(items[limit-1])...)
+	return items, &cursor
+}
+
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
+
+// timeOrder returns the keyset comparison operator for the order and the
+// key substituted for missing timestamps so that they go last.
+func timeOrder(order api.SortOrder) (cmp, nullKey string, err error) {
+	switch order {
+	case api.Asc:
+		return ">", "infinity", nil
+	case api.Desc:
+		return "<", "-infinity", nil
+	}
+	return "", "", ErrInvalidListParams
+}
+
+func timeKey(t *time.Time, nullKey string) string {
+	if t == nil {
+		return nullKey
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func isTimeKey(key, nullKey string) bool {
+	if key == nullKey {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339Nano, key)
+	return err == nil
+}
+
+// utc converts filter bounds to the UTC wall clock stored in
+// timestamp columns.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// addEvent appends an assignment event in the transaction of the change it
+// describes, publishes it to the webhook outbox and event streams and
+// schedules the reviewer sync of a linked pull request and notifications
+// to reviewers. Empty actor and reason are stored as NULL.
+func (s *Storage) addEvent(ctx context.Context, tx pgx.Tx, event api.AssignmentEvent) error {
+	event.ActorId = nullIfEmpty(event.ActorId)
+	event.Reason = nullIfEmpty(event.Reason)
+	if event.Reviewers == nil {
+		event.Reviewers = []string{}
+	}
+
+	sql := `INSERT INTO assignment_events
+	(pull_request_id, event_type, actor_id, old_user_id, new_user_id, reviewers, reason)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING event_id, createdAt`
+	err := tx.QueryRow(
+		ctx,
+		sql,
+		event.PullRequestId,
+		event.EventType,
+		event.ActorId,
+		event.OldUserId,
+		event.NewUserId,
+		event.Reviewers,
+		event.Reason,
+	).Scan(&event.EventId, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres.addEvent failed to insert event: %w", err)
+	}
+
+	if event.EventType != api.Merged {
+		if _, err := s.requestReviewerSync(ctx, tx, event.PullRequestId); err != nil {
+			return err
+		}
+	}
+
+	if err := s.enqueueNotifications(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := s.notifyEvent(ctx, tx, event.EventId); err != nil {
+		return err
+	}
+
+	return s.publish(ctx, tx, webhookEventType(event.EventType), event)
+}
+
+func nullIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+func (s *Storage) GetPullRequestHistory(
+	ctx context.Context,
+	params api.GetPullRequestHistoryParams,
+) (*api.AssignmentEventList, error) {
+	const op = "postgres.GetPullRequestHistory"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if ok, err := s.IsPullRequestExists(ctx, tx, params.PullRequestId); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrPullRequestNotFound
+	}
+
+	events, err := s.listEvents(
+		ctx,
+		tx,
+		"pull_request_id = $1",
+		params.PullRequestId,
+		params.Order,
+		params.Limit,
+		params.Cursor,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// GetUserHistory returns events where the user acted, was removed or assigned,
+// or was among the reviewers of the pull request.
+func (s *Storage) GetUserHistory(
+	ctx context.Context,
+	params api.GetUsersHistoryParams,
+) (*api.AssignmentEventList, error) {
+	const op = "postgres.GetUserHistory"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if ok, err := s.IsUserExists(ctx, tx, params.UserId); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	events, err := s.listEvents(
+		ctx,
+		tx,
+		"($1 IN (actor_id, old_user_id, new_user_id) OR reviewers @> ARRAY[$1])",
+		params.UserId,
+		params.Order,
+		params.Limit,
+		params.Cursor,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return events, nil
+}
+
+func (s *Storage) listEvents(
+	ctx context.Context,
+	tx pgx.Tx,
+	filter, arg string,
+	order *api.SortOrder,
+	limit *int,
+	cursor *string,
+) (*api.AssignmentEventList, error) {
+	const op = "postgres.listEvents"
+
+	direction := api.Desc
+	if order != nil {
+		direction = *order
+	}
+	cmp, _, err := timeOrder(direction)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *int64
+	if cursor != nil {
+		keys, err := decodeCursor(*cursor, 2)
+		if err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseInt(keys[1], 10, 64)
+		if err != nil || keys[0] != string(direction) {
+			return nil, ErrInvalidCursor
+		}
+		after = &id
+	}
+
+	n := pageLimit(limit)
+	sql := fmt.Sprintf(assignmentEventSelect+`
+	WHERE %[1]s
+		AND ($2::bigint IS NULL OR event_id %[2]s $2)
+	ORDER BY event_id %[3]s
+	LIMIT $3`, filter, cmp, direction)
+	rows, err := tx.Query(ctx, sql, arg, after, n+1)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.AssignmentEvent, error) {
+		return scanAssignmentEvent(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	events, next := nextPage(events, n, func(e api.AssignmentEvent) []string {
+		return []string{string(direction), strconv.FormatInt(e.EventId, 10)}
+	})
+	return &api.AssignmentEventList{
+		Events:     events,
+		NextCursor: next,
+	}, nil
+}
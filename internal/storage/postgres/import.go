@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportTeams applies an org chart describing every team in one transaction.
+// Users missing from the chart are deactivated only with DeactivateMissing
+// set. With DryRun set nothing is written and only the report of planned
+// changes is returned.
+func (s *Storage) ImportTeams(ctx context.Context, teams []api.Team, params api.PostImportParams) (*api.ImportReport, error) {
+	const op = "postgres.ImportTeams"
+	dryRun := params.DryRun != nil && *params.DryRun
+	deactivateMissing := params.DeactivateMissing != nil && *params.DeactivateMissing
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	rows, err := tx.Query(ctx,
+		`SELECT user_id, username, team_name, is_active, role FROM users
+		ORDER BY user_id
+		FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query users: %w", op, err)
+	}
+	users, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (importedUser, error) {
+		var u importedUser
+		return u, row.Scan(&u.UserId, &u.Username, &u.TeamName, &u.IsActive, &u.Role)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect users: %w", op, err)
+	}
+
+	existing := make(map[string]importedUser, len(users))
+	for _, u := range users {
+		existing[u.UserId] = u
+	}
+
+	report := &api.ImportReport{
+		DryRun:        dryRun,
+		Created:       []string{},
+		Updated:       []string{},
+		Moved:         []api.ImportMove{},
+		Deactivated:   []string{},
+		Reassignments: []api.Reassignment{},
+	}
+
+	sql := `INSERT INTO users (user_id, username, team_name, is_active, role)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (user_id)
+	DO UPDATE SET
+		username = EXCLUDED.username,
+		team_name = EXCLUDED.team_name,
+		is_active = EXCLUDED.is_active,
+		role = EXCLUDED.role`
+	for _, t := range teams {
+		for _, m := range t.Members {
+			role := api.Member
+			if m.Role != nil {
+				role = *m.Role
+			}
+
+			old, ok := existing[m.UserId]
+			delete(existing, m.UserId)
+			switch {
+			case !ok:
+				report.Created = append(report.Created, m.UserId)
+			case old.IsActive && !m.IsActive:
+				report.Deactivated = append(report.Deactivated, m.UserId)
+			case old.Username != m.Username || old.Role != role || old.IsActive != m.IsActive:
+				report.Updated = append(report.Updated, m.UserId)
+			}
+			if ok && old.TeamName != t.TeamName {
+				report.Moved = append(report.Moved, api.ImportMove{
+					UserId:   m.UserId,
+					FromTeam: old.TeamName,
+					ToTeam:   t.TeamName,
+				})
+			}
+
+			if dryRun {
+				continue
+			}
+			if _, err := tx.Exec(ctx, sql, m.UserId, m.Username, t.TeamName, m.IsActive, role); err != nil {
+				return nil, fmt.Errorf("%v failed to upsert user: %w", op, err)
+			}
+		}
+	}
+
+	var missing []string
+	for _, u := range users {
+		if _, ok := existing[u.UserId]; ok && u.IsActive && deactivateMissing {
+			missing = append(missing, u.UserId)
+		}
+	}
+	report.Deactivated = append(report.Deactivated, missing...)
+	slices.Sort(report.Deactivated)
+
+	if dryRun {
+		return report, nil
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE users SET is_active = false WHERE user_id = ANY($1)", missing); err != nil {
+		return nil, fmt.Errorf("%v failed to deactivate missing users: %w", op, err)
+	}
+
+	report.Reassignments, err = s.replaceInactiveReviewers(ctx, tx, report.Deactivated, "", "org chart import")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return report, nil
+}
+
+type importedUser struct {
+	UserId   string
+	Username string
+	TeamName string
+	IsActive bool
+	Role     api.TeamMemberRole
+}
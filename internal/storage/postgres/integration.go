@@ -0,0 +1,332 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ExternalPullRequest links a pull request to its origin on a code host.
+type ExternalPullRequest struct {
+	Provider      api.CodeHostProvider
+	Repository    string
+	Number        int
+	PullRequestId string
+}
+
+// PendingReviewerSync is a reviewer sync claimed by the syncer. Reviewers
+// are code host logins of the assigned reviewers that have an account
+// mapping, SyncedReviewers are the logins requested by the previous sync.
+type PendingReviewerSync struct {
+	PullRequestId   string
+	Provider        api.CodeHostProvider
+	Repository      string
+	Number          int
+	Version         int64
+	Attempts        int
+	Reviewers       []string
+	SyncedReviewers []string
+}
+
+const reviewerSyncSelect = `SELECT
+		provider,
+		repository,
+		number,
+		sync_status,
+		sync_attempts,
+		last_sync_error,
+		syncedAt
+	FROM external_pull_requests`
+
+// SetCodeHostAccount maps the code host login to the user. Logins are
+// stored in lower case as code hosts compare them case-insensitively.
+func (s *Storage) SetCodeHostAccount(ctx context.Context, account api.CodeHostAccount) (*api.CodeHostAccount, error) {
+	const op = "postgres.SetCodeHostAccount"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if ok, err := s.IsUserExists(ctx, tx, account.UserId); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	sql := `INSERT INTO code_host_accounts (provider, login, user_id)
+	VALUES ($1, lower($2), $3)
+	ON CONFLICT (provider, login)
+	DO UPDATE SET user_id = EXCLUDED.user_id
+	RETURNING provider, login, user_id`
+	saved, err := scanCodeHostAccount(tx.QueryRow(ctx, sql, account.Provider, account.Login, account.UserId))
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query row: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &saved, nil
+}
+
+func (s *Storage) ListCodeHostAccounts(
+	ctx context.Context,
+	provider *api.CodeHostProvider,
+) ([]api.CodeHostAccount, error) {
+	const op = "postgres.ListCodeHostAccounts"
+	rows, err := s.db.Query(ctx, `SELECT provider, login, user_id FROM code_host_accounts
+	WHERE $1::text IS NULL OR provider = $1
+	ORDER BY provider, login`, provider)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	accounts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.CodeHostAccount, error) {
+		return scanCodeHostAccount(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return accounts, nil
+}
+
+func (s *Storage) DeleteCodeHostAccount(
+	ctx context.Context,
+	provider api.CodeHostProvider,
+	login string,
+) (*api.CodeHostAccount, error) {
+	sql := `DELETE FROM code_host_accounts WHERE provider = $1 AND login = lower($2)
+	RETURNING provider, login, user_id`
+	account, err := scanCodeHostAccount(s.db.QueryRow(ctx, sql, provider, login))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.DeleteCodeHostAccount failed to query row: %w", err)
+	}
+	return &account, nil
+}
+
+func (s *Storage) GetCodeHostUserId(ctx context.Context, provider api.CodeHostProvider, login string) (string, error) {
+	sql := "SELECT user_id FROM code_host_accounts WHERE provider = $1 AND login = lower($2)"
+	var userId string
+	if err := s.db.QueryRow(ctx, sql, provider, login).Scan(&userId); errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrAccountNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("postgres.GetCodeHostUserId failed to query row: %w", err)
+	}
+	return userId, nil
+}
+
+// LinkExternalPullRequest records the origin of the pull request.
+// Linking is idempotent so that redelivered events are harmless.
+func (s *Storage) LinkExternalPullRequest(ctx context.Context, link ExternalPullRequest) error {
+	sql := `INSERT INTO external_pull_requests (provider, repository, number, pull_request_id)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT DO NOTHING`
+	_, err := s.db.Exec(ctx, sql, link.Provider, link.Repository, link.Number, link.PullRequestId)
+	if err != nil {
+		return fmt.Errorf("postgres.LinkExternalPullRequest failed to insert link: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) GetExternalPullRequestId(
+	ctx context.Context,
+	provider api.CodeHostProvider,
+	repository string,
+	number int,
+) (string, error) {
+	sql := `SELECT pull_request_id FROM external_pull_requests
+	WHERE provider = $1 AND repository = $2 AND number = $3`
+	var prId string
+	if err := s.db.QueryRow(ctx, sql, provider, repository, number).Scan(&prId); errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrPullRequestNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("postgres.GetExternalPullRequestId failed to query row: %w", err)
+	}
+	return prId, nil
+}
+
+func scanCodeHostAccount(row pgx.Row) (api.CodeHostAccount, error) {
+	var a api.CodeHostAccount
+	return a, row.Scan(&a.Provider, &a.Login, &a.UserId)
+}
+
+// GetReviewerSync returns the reviewer sync state of the pull request,
+// nil for pull requests not linked to a code host.
+func (s *Storage) GetReviewerSync(ctx context.Context, prId string) (*api.ReviewerSync, error) {
+	sync, err := scanReviewerSync(s.db.QueryRow(ctx, reviewerSyncSelect+`
+	WHERE pull_request_id = $1`, prId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.GetReviewerSync failed to query row: %w", err)
+	}
+	return &sync, nil
+}
+
+// RetryReviewerSync puts the sync of the linked pull request back to
+// the queue with the attempt counter reset.
+func (s *Storage) RetryReviewerSync(ctx context.Context, prId string) (*api.ReviewerSync, error) {
+	const op = "postgres.RetryReviewerSync"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if ok, err := s.requestReviewerSync(ctx, tx, prId); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrPullRequestNotFound
+	}
+
+	sync, err := scanReviewerSync(tx.QueryRow(ctx, reviewerSyncSelect+`
+	WHERE pull_request_id = $1`, prId))
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query row: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &sync, nil
+}
+
+// requestReviewerSync schedules the sync of a linked pull request in the
+// transaction that changes its reviewers. Bumping the version keeps a sync
+// in flight from marking the newer reviewers as synced.
+func (s *Storage) requestReviewerSync(ctx context.Context, tx pgx.Tx, prId string) (bool, error) {
+	sql := `UPDATE external_pull_requests
+	SET
+		sync_status = 'pending',
+		sync_version = sync_version + 1,
+		sync_attempts = 0,
+		next_sync_at = NOW(),
+		last_sync_error = NULL
+	WHERE pull_request_id = $1`
+	tag, err := tx.Exec(ctx, sql, prId)
+	if err != nil {
+		return false, fmt.Errorf("postgres.requestReviewerSync failed to execute update: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ClaimReviewerSyncs returns due syncs of the providers and postpones them
+// by the lease so that concurrent syncers skip syncs in flight.
+func (s *Storage) ClaimReviewerSyncs(
+	ctx context.Context,
+	providers []api.CodeHostProvider,
+	limit int,
+	lease time.Duration,
+) ([]PendingReviewerSync, error) {
+	const op = "postgres.ClaimReviewerSyncs"
+	sql := `UPDATE external_pull_requests e
+	SET next_sync_at = NOW() + $3::interval
+	FROM pull_requests p
+	WHERE e.pull_request_id IN (
+			SELECT pull_request_id FROM external_pull_requests
+			WHERE sync_status = 'pending' AND next_sync_at <= NOW() AND provider = ANY($1)
+			ORDER BY next_sync_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		AND p.pull_request_id = e.pull_request_id
+	RETURNING
+		e.pull_request_id,
+		e.provider,
+		e.repository,
+		e.number,
+		e.sync_version,
+		e.sync_attempts,
+		ARRAY(
+			SELECT DISTINCT ON (a.user_id) a.login
+			FROM code_host_accounts a
+			WHERE a.provider = e.provider AND a.user_id = ANY(p.assigned_reviewers)
+			ORDER BY a.user_id, a.login
+		),
+		e.synced_reviewers`
+	rows, err := s.db.Query(ctx, sql, providers, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	syncs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (PendingReviewerSync, error) {
+		var p PendingReviewerSync
+		return p, row.Scan(
+			&p.PullRequestId,
+			&p.Provider,
+			&p.Repository,
+			&p.Number,
+			&p.Version,
+			&p.Attempts,
+			&p.Reviewers,
+			&p.SyncedReviewers,
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return syncs, nil
+}
+
+// MarkReviewerSynced records the requested logins. The sync stays pending
+// if reviewers changed since it was claimed.
+func (s *Storage) MarkReviewerSynced(ctx context.Context, prId string, version int64, reviewers []string) error {
+	sql := `UPDATE external_pull_requests
+	SET
+		sync_status = CASE WHEN sync_version = $2 THEN 'synced' ELSE 'pending' END::reviewer_sync_status,
+		next_sync_at = NOW(),
+		last_sync_error = NULL,
+		synced_reviewers = $3,
+		syncedAt = NOW()
+	WHERE pull_request_id = $1`
+	if _, err := s.db.Exec(ctx, sql, prId, version, reviewers); err != nil {
+		return fmt.Errorf("postgres.MarkReviewerSynced failed to execute update: %w", err)
+	}
+	return nil
+}
+
+// MarkReviewerSyncFailed records a failed attempt unless reviewers changed
+// since the sync was claimed. Without retryIn the sync is moved to
+// the failed state.
+func (s *Storage) MarkReviewerSyncFailed(
+	ctx context.Context,
+	prId string,
+	version int64,
+	lastError string,
+	retryIn *time.Duration,
+) error {
+	sql := `UPDATE external_pull_requests
+	SET
+		sync_status = CASE WHEN $4::interval IS NULL THEN 'failed' ELSE 'pending' END::reviewer_sync_status,
+		sync_attempts = sync_attempts + 1,
+		last_sync_error = $3,
+		next_sync_at = NOW() + COALESCE($4::interval, INTERVAL '0')
+	WHERE pull_request_id = $1 AND sync_version = $2`
+	if _, err := s.db.Exec(ctx, sql, prId, version, lastError, retryIn); err != nil {
+		return fmt.Errorf("postgres.MarkReviewerSyncFailed failed to execute update: %w", err)
+	}
+	return nil
+}
+
+func scanReviewerSync(row pgx.Row) (api.ReviewerSync, error) {
+	var sync api.ReviewerSync
+	return sync, row.Scan(
+		&sync.Provider,
+		&sync.Repository,
+		&sync.Number,
+		&sync.Status,
+		&sync.Attempts,
+		&sync.LastError,
+		&sync.SyncedAt,
+	)
+}
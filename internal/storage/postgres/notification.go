@@ -0,0 +1,403 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NotificationTransport is the channel a notification is sent through.
+type NotificationTransport string
+
+const (
+	ChatTransport  NotificationTransport = "chat"
+	EmailTransport NotificationTransport = "email"
+)
+
+// PendingNotification is a notification claimed by a notifier together
+// with the event it is about and the contact of the recipient.
+type PendingNotification struct {
+	NotificationId  int64
+	Attempts        int
+	Kind            api.NotificationKind
+	Contact         api.NotificationContact
+	PullRequestName string
+	Event           api.AssignmentEvent
+}
+
+// Reminder lists open pull requests waiting for the review of the user.
+type Reminder struct {
+	UserId       string
+	Email        string
+	PullRequests []api.PullRequest
+}
+
+// SetNotificationTransports limits the queued notifications to the given
+// transports, the ones without a running notifier would never be sent.
+// It must be called before the storage is used.
+func (s *Storage) SetNotificationTransports(transports ...NotificationTransport) {
+	s.transports = []string{}
+	for _, t := range transports {
+		s.transports = append(s.transports, string(t))
+	}
+}
+
+const notificationContactColumns = `user_id, chat_channel, chat_handle, email, email_opt_out`
+
+func (s *Storage) SetNotificationContact(
+	ctx context.Context,
+	contact api.NotificationContact,
+) (*api.NotificationContact, error) {
+	const op = "postgres.SetNotificationContact"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if ok, err := s.IsUserExists(ctx, tx, contact.UserId); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	optOut := []api.NotificationKind{}
+	if contact.EmailOptOut != nil {
+		optOut = *contact.EmailOptOut
+	}
+
+	sql := `INSERT INTO notification_contacts (user_id, chat_channel, chat_handle, email, email_opt_out)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (user_id)
+	DO UPDATE SET
+		chat_channel = EXCLUDED.chat_channel,
+		chat_handle = EXCLUDED.chat_handle,
+		email = EXCLUDED.email,
+		email_opt_out = EXCLUDED.email_opt_out,
+		updatedAt = NOW()
+	RETURNING ` + notificationContactColumns
+	saved, err := scanNotificationContact(tx.QueryRow(
+		ctx,
+		sql,
+		contact.UserId,
+		nullIfEmpty(contact.ChatChannel),
+		nullIfEmpty(contact.ChatHandle),
+		contact.Email,
+		optOut,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query row: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &saved, nil
+}
+
+func (s *Storage) ListNotificationContacts(ctx context.Context) ([]api.NotificationContact, error) {
+	const op = "postgres.ListNotificationContacts"
+	rows, err := s.db.Query(ctx, `SELECT `+notificationContactColumns+`
+	FROM notification_contacts
+	ORDER BY user_id`)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	contacts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.NotificationContact, error) {
+		return scanNotificationContact(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return contacts, nil
+}
+
+// DeleteNotificationContact removes the contact and drops notifications
+// still pending for the user.
+func (s *Storage) DeleteNotificationContact(ctx context.Context, userId string) (*api.NotificationContact, error) {
+	const op = "postgres.DeleteNotificationContact"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	contact, err := scanNotificationContact(tx.QueryRow(ctx, `DELETE FROM notification_contacts
+	WHERE user_id = $1
+	RETURNING `+notificationContactColumns, userId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrContactNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("%v failed to query row: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM notifications WHERE user_id = $1 AND status = 'pending'", userId)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to delete pending notifications: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &contact, nil
+}
+
+// enqueueNotifications schedules notifications about the event through
+// every enabled transport the recipient has a contact for, respecting
+// email opt-outs. The actor is not notified of own actions.
+func (s *Storage) enqueueNotifications(ctx context.Context, tx pgx.Tx, event api.AssignmentEvent) error {
+	userIds, kinds := notificationRecipients(event)
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	sql := `INSERT INTO notifications (event_id, user_id, transport, kind)
+	SELECT $1, c.user_id, t.transport, r.kind
+	FROM unnest($2::text[], $3::text[]) AS r(user_id, kind)
+		JOIN notification_contacts c ON c.user_id = r.user_id
+		CROSS JOIN unnest(enum_range(NULL::notification_transport)) AS t(transport)
+	WHERE c.user_id IS DISTINCT FROM $4
+		AND ($5::text[] IS NULL OR t.transport::text = ANY($5::text[]))
+		AND CASE t.transport
+			WHEN 'chat' THEN c.chat_channel IS NOT NULL OR c.chat_handle IS NOT NULL
+			WHEN 'email' THEN c.email IS NOT NULL AND NOT r.kind = ANY(c.email_opt_out)
+		END`
+	if _, err := tx.Exec(ctx, sql, event.EventId, userIds, kinds, event.ActorId, s.transports); err != nil {
+		return fmt.Errorf("postgres.enqueueNotifications failed to insert notifications: %w", err)
+	}
+	return nil
+}
+
+// notificationRecipients returns reviewers concerned by the event with
+// the kind of notification for each: all reviewers on creation and merge,
+// the assigned reviewer on replacement and the removed one when somebody
+// else reassigned them.
+func notificationRecipients(event api.AssignmentEvent) ([]string, []api.NotificationKind) {
+	var (
+		userIds []string
+		kinds   []api.NotificationKind
+	)
+	add := func(userId *string, kind api.NotificationKind) {
+		if userId != nil {
+			userIds = append(userIds, *userId)
+			kinds = append(kinds, kind)
+		}
+	}
+
+	switch event.EventType {
+	case api.Created:
+		for _, id := range event.Reviewers {
+			add(&id, api.KindAssigned)
+		}
+	case api.Merged:
+		for _, id := range event.Reviewers {
+			add(&id, api.KindMerged)
+		}
+	case api.Reassigned:
+		add(event.NewUserId, api.KindAssigned)
+		add(event.OldUserId, api.KindUnassigned)
+	case api.Declined, api.DeactivatedReplaced:
+		add(event.NewUserId, api.KindAssigned)
+	}
+	return userIds, kinds
+}
+
+// ClaimNotifications returns due notifications of the transport and
+// postpones them by the lease so that concurrent notifiers skip them.
+func (s *Storage) ClaimNotifications(
+	ctx context.Context,
+	transport NotificationTransport,
+	limit int,
+	lease time.Duration,
+) ([]PendingNotification, error) {
+	const op = "postgres.ClaimNotifications"
+	sql := `UPDATE notifications n
+	SET next_attempt_at = NOW() + $3::interval
+	FROM assignment_events e, pull_requests p, notification_contacts c
+	WHERE n.notification_id IN (
+			SELECT notification_id FROM notifications
+			WHERE transport = $1 AND status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		AND e.event_id = n.event_id
+		AND p.pull_request_id = e.pull_request_id
+		AND c.user_id = n.user_id
+	RETURNING
+		n.notification_id,
+		n.attempts,
+		n.kind,
+		c.user_id,
+		c.chat_channel,
+		c.chat_handle,
+		c.email,
+		c.email_opt_out,
+		p.pull_request_name,
+		e.event_id,
+		e.pull_request_id,
+		e.event_type,
+		e.actor_id,
+		e.old_user_id,
+		e.new_user_id,
+		e.reviewers,
+		e.reason,
+		e.createdAt`
+	rows, err := s.db.Query(ctx, sql, transport, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	notifications, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (PendingNotification, error) {
+		var n PendingNotification
+		return n, row.Scan(
+			&n.NotificationId,
+			&n.Attempts,
+			&n.Kind,
+			&n.Contact.UserId,
+			&n.Contact.ChatChannel,
+			&n.Contact.ChatHandle,
+			&n.Contact.Email,
+			&n.Contact.EmailOptOut,
+			&n.PullRequestName,
+			&n.Event.EventId,
+			&n.Event.PullRequestId,
+			&n.Event.EventType,
+			&n.Event.ActorId,
+			&n.Event.OldUserId,
+			&n.Event.NewUserId,
+			&n.Event.Reviewers,
+			&n.Event.Reason,
+			&n.Event.CreatedAt,
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return notifications, nil
+}
+
+func (s *Storage) MarkNotificationSent(ctx context.Context, notificationId int64) error {
+	sql := `UPDATE notifications
+	SET
+		status = 'delivered',
+		attempts = attempts + 1,
+		last_error = NULL,
+		sentAt = NOW()
+	WHERE notification_id = $1`
+	if _, err := s.db.Exec(ctx, sql, notificationId); err != nil {
+		return fmt.Errorf("postgres.MarkNotificationSent failed to execute update: %w", err)
+	}
+	return nil
+}
+
+// MarkNotificationFailed records a failed attempt. Without retryIn
+// the notification is given up.
+func (s *Storage) MarkNotificationFailed(
+	ctx context.Context,
+	notificationId int64,
+	lastError string,
+	retryIn *time.Duration,
+) error {
+	sql := `UPDATE notifications
+	SET
+		status = CASE WHEN $3::interval IS NULL THEN 'dead' ELSE 'pending' END::delivery_status,
+		attempts = attempts + 1,
+		last_error = $2,
+		next_attempt_at = NOW() + COALESCE($3::interval, INTERVAL '0')
+	WHERE notification_id = $1`
+	if _, err := s.db.Exec(ctx, sql, notificationId, lastError, retryIn); err != nil {
+		return fmt.Errorf("postgres.MarkNotificationFailed failed to execute update: %w", err)
+	}
+	return nil
+}
+
+// ClaimEmailReminders picks contacts not reminded for the period and
+// returns their open reviews older than olderThan. Contacts are marked
+// reminded when claimed, so a failed reminder is skipped until the next
+// period.
+func (s *Storage) ClaimEmailReminders(
+	ctx context.Context,
+	period, olderThan time.Duration,
+	limit int,
+) ([]Reminder, error) {
+	const op = "postgres.ClaimEmailReminders"
+	sql := `WITH due AS (
+		UPDATE notification_contacts
+		SET last_reminded_at = NOW()
+		WHERE user_id IN (
+			SELECT user_id FROM notification_contacts
+			WHERE email IS NOT NULL
+				AND NOT 'reminder' = ANY(email_opt_out)
+				AND (last_reminded_at IS NULL OR last_reminded_at <= NOW() - $1::interval)
+			ORDER BY user_id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING user_id, email
+	)
+	SELECT
+		d.user_id,
+		d.email,
+		p.pull_request_id,
+		p.pull_request_name,
+		p.author_id,
+		p.assigned_reviewers,
+		p.status,
+		p.createdAt,
+		p.mergedAt
+	FROM due d
+		JOIN pull_requests p ON d.user_id = ANY(p.assigned_reviewers)
+	WHERE p.status = 'OPEN' AND p.createdAt <= NOW() - $2::interval
+	ORDER BY d.user_id, p.createdAt, p.pull_request_id`
+	rows, err := s.db.Query(ctx, sql, period, olderThan, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reminders []Reminder
+	for rows.Next() {
+		var (
+			userId, email string
+			pr            api.PullRequest
+		)
+		err := rows.Scan(
+			&userId,
+			&email,
+			&pr.PullRequestId,
+			&pr.PullRequestName,
+			&pr.AuthorId,
+			&pr.AssignedReviewers,
+			&pr.Status,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%v failed to scan row: %w", op, err)
+		}
+
+		if n := len(reminders); n == 0 || reminders[n-1].UserId != userId {
+			reminders = append(reminders, Reminder{UserId: userId, Email: email})
+		}
+		last := &reminders[len(reminders)-1]
+		last.PullRequests = append(last.PullRequests, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%v failed to read rows: %w", op, err)
+	}
+	return reminders, nil
+}
+
+func scanNotificationContact(row pgx.Row) (api.NotificationContact, error) {
+	var c api.NotificationContact
+	return c, row.Scan(&c.UserId, &c.ChatChannel, &c.ChatHandle, &c.Email, &c.EmailOptOut)
+}
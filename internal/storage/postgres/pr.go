@@ -11,34 +11,54 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-func (s *Storage) Merge(ctx context.Context, pullRequestId string) (*api.PullRequest, error) {
-	sql := `UPDATE pull_requests 
-		SET status = $1 
-	WHERE pull_request_id = $2 
-	RETURNING pull_request_id, pull_request_name, status, author_id, assigned_reviewers, createdAt, mergedAt`
-
-	var request api.PullRequest
-	err := s.db.QueryRow(ctx, sql, api.PullRequestShortStatusMERGED, pullRequestId).Scan(
-		&request.PullRequestId,
-		&request.PullRequestName,
-		&request.Status,
-		&request.AuthorId,
-		&request.AssignedReviewers,
-		&request.CreatedAt,
-		&request.MergedAt,
-	)
+// Merge marks the pull request as merged. Merging is idempotent, the merged
+// event is recorded only on the transition from OPEN.
+func (s *Storage) Merge(ctx context.Context, pullRequestId, actorId string) (*api.PullRequest, error) {
+	const op = "postgres.Merge"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
 
+	sql := `UPDATE pull_requests
+		SET status = $1
+	WHERE pull_request_id = $2 AND status != $1
+	RETURNING pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt, mergedAt`
+
+	request, err := scanPullRequest(tx.QueryRow(ctx, sql, api.PullRequestShortStatusMERGED, pullRequestId))
 	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, ErrPullRequestNotFound
+		return s.GetPullRequest(ctx, tx, pullRequestId)
 	} else if err != nil {
-		return nil, fmt.Errorf("postgres.Merge failed query row: %w", err)
+		return nil, fmt.Errorf("%v failed query row: %w", op, err)
+	}
+
+	err = s.addEvent(ctx, tx, api.AssignmentEvent{
+		PullRequestId: pullRequestId,
+		EventType:     api.Merged,
+		ActorId:       &actorId,
+		Reviewers:     request.AssignedReviewers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
 	}
 
 	return &request, nil
 }
 
-func (s *Storage) Reassign(ctx context.Context, pullRequestId, userId string) (*api.PullRequest, string, error) {
+// Reassign replaces the reviewer with a random active member of the author
+// team. A reviewer replacing themselves is recorded as declined.
+func (s *Storage) Reassign(
+	ctx context.Context,
+	req api.PostPullRequestReassignJSONBody,
+	actorId string,
+) (*api.PullRequest, string, error) {
 	const op = "postgres.Reassign"
+	pullRequestId, userId := req.PullRequestId, req.OldUserId
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, "", fmt.Errorf("%v failed to begin transaction: %w", op, err)
@@ -81,6 +101,23 @@ func (s *Storage) Reassign(ctx context.Context, pullRequestId, userId string) (*
 		return nil, "", fmt.Errorf("%v failed to execute update: %w", op, err)
 	}
 
+	eventType := api.Reassigned
+	if actorId == userId {
+		eventType = api.Declined
+	}
+	err = s.addEvent(ctx, tx, api.AssignmentEvent{
+		PullRequestId: pullRequestId,
+		EventType:     eventType,
+		ActorId:       &actorId,
+		OldUserId:     &userId,
+		NewUserId:     &candidate[0],
+		Reviewers:     pr.AssignedReviewers,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, "", fmt.Errorf("%v failed to commit transaction: %w", op, err)
 	}
@@ -91,6 +128,7 @@ func (s *Storage) Reassign(ctx context.Context, pullRequestId, userId string) (*
 func (s *Storage) CreatePullRequest(
 	ctx context.Context,
 	req api.PostPullRequestCreateJSONBody,
+	actorId string,
 ) (*api.PullRequest, error) {
 	const op = "postgres.CreatePullRequest"
 	tx, err := s.db.Begin(ctx)
@@ -140,6 +178,16 @@ func (s *Storage) CreatePullRequest(
 		return nil, fmt.Errorf("%v failed to query row: %w", op, err)
 	}
 
+	err = s.addEvent(ctx, tx, api.AssignmentEvent{
+		PullRequestId: pr.PullRequestId,
+		EventType:     api.Created,
+		ActorId:       &actorId,
+		Reviewers:     pr.AssignedReviewers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
 	}
@@ -185,19 +233,139 @@ func (s *Storage) IsPullRequestExists(ctx context.Context, tx pgx.Tx, prId strin
 	return ok, nil
 }
 
+const pullRequestSelect = `SELECT
+		p.pull_request_id,
+		p.pull_request_name,
+		p.author_id,
+		p.assigned_reviewers,
+		p.status,
+		p.createdAt,
+		p.mergedAt
+	FROM pull_requests p`
+
 func (s *Storage) GetPullRequest(ctx context.Context, tx pgx.Tx, prId string) (*api.PullRequest, error) {
-	sql := `SELECT 
-		pull_request_id,
-		pull_request_name, 
-		author_id,
-		assigned_reviewers,
-		status,
-		createdAt,
-		mergedAt 
-	FROM pull_requests
-	WHERE pull_request_id = $1`
+	pr, err := scanPullRequest(tx.QueryRow(ctx, pullRequestSelect+`
+	WHERE p.pull_request_id = $1`, prId))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("postgres.GetPullRequests failed to query row: %w", err)
+	} else if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPullRequestNotFound
+	}
+	return &pr, nil
+}
+
+// GetPullRequestById fetches a pull request outside of any transaction.
+func (s *Storage) GetPullRequestById(ctx context.Context, prId string) (*api.PullRequest, error) {
+	pr, err := scanPullRequest(s.db.QueryRow(ctx, pullRequestSelect+`
+	WHERE p.pull_request_id = $1`, prId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPullRequestNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.GetPullRequestById failed to query row: %w", err)
+	}
+	return &pr, nil
+}
+
+// ListPullRequests returns a page of pull requests ordered by the requested
+// timestamp with pull_request_id as a tie breaker. Pull requests missing
+// the timestamp go last in both directions.
+func (s *Storage) ListPullRequests(
+	ctx context.Context,
+	params api.GetPullRequestListParams,
+) (*api.PullRequestList, error) {
+	const op = "postgres.ListPullRequests"
+
+	sort, order := api.CreatedAt, api.Desc
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+	if params.Order != nil {
+		order = *params.Order
+	}
+
+	column := "p.createdAt"
+	switch sort {
+	case api.CreatedAt:
+	case api.MergedAt:
+		column = "p.mergedAt"
+	default:
+		return nil, ErrInvalidListParams
+	}
+	cmp, nullKey, err := timeOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	if params.Status != nil && *params.Status != api.GetPullRequestListParamsStatusOPEN &&
+		*params.Status != api.GetPullRequestListParamsStatusMERGED {
+		return nil, ErrInvalidListParams
+	}
+
+	var afterKey, afterId *string
+	if params.Cursor != nil {
+		keys, err := decodeCursor(*params.Cursor, 4)
+		if err != nil {
+			return nil, err
+		}
+		if keys[0] != string(sort) || keys[1] != string(order) || !isTimeKey(keys[2], nullKey) {
+			return nil, ErrInvalidCursor
+		}
+		afterKey, afterId = &keys[2], &keys[3]
+	}
+
+	key := fmt.Sprintf("COALESCE(%s, '%s')", column, nullKey)
+	sql := fmt.Sprintf(pullRequestSelect+`
+		JOIN users u ON u.user_id = p.author_id
+	WHERE ($1::pr_status IS NULL OR p.status = $1)
+		AND ($2::text IS NULL OR p.author_id = $2)
+		AND ($3::text IS NULL OR $3 = ANY(p.assigned_reviewers))
+		AND ($4::text IS NULL OR u.team_name = $4)
+		AND ($5::timestamp IS NULL OR p.createdAt >= $5)
+		AND ($6::timestamp IS NULL OR p.createdAt < $6)
+		AND ($7::timestamp IS NULL OR p.mergedAt >= $7)
+		AND ($8::timestamp IS NULL OR p.mergedAt < $8)
+		AND ($9::timestamp IS NULL OR (%[1]s, p.pull_request_id) %[2]s ($9, $10))
+	ORDER BY %[1]s %[3]s, p.pull_request_id %[3]s
+	LIMIT $11`, key, cmp, order)
+	rows, err := s.db.Query(ctx, sql,
+		params.Status,
+		params.AuthorId,
+		params.ReviewerId,
+		params.TeamName,
+		utc(params.CreatedFrom),
+		utc(params.CreatedTo),
+		utc(params.MergedFrom),
+		utc(params.MergedTo),
+		afterKey,
+		afterId,
+		pageLimit(params.Limit)+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	prs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.PullRequest, error) {
+		return scanPullRequest(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	prs, next := nextPage(prs, pageLimit(params.Limit), func(pr api.PullRequest) []string {
+		t := pr.CreatedAt
+		if sort == api.MergedAt {
+			t = pr.MergedAt
+		}
+		return []string{string(sort), string(order), timeKey(t, nullKey), pr.PullRequestId}
+	})
+	return &api.PullRequestList{
+		PullRequests: prs,
+		NextCursor:   next,
+	}, nil
+}
+
+func scanPullRequest(row pgx.Row) (api.PullRequest, error) {
 	var pr api.PullRequest
-	err := tx.QueryRow(ctx, sql, prId).Scan(
+	return pr, row.Scan(
 		&pr.PullRequestId,
 		&pr.PullRequestName,
 		&pr.AuthorId,
@@ -206,10 +374,100 @@ func (s *Storage) GetPullRequest(ctx context.Context, tx pgx.Tx, prId string) (*
 		&pr.CreatedAt,
 		&pr.MergedAt,
 	)
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		return nil, fmt.Errorf("postgres.GetPullRequests failed to query row: %w", err)
+}
+
+func (s *Storage) GetPullRequestTeamName(ctx context.Context, prId string) (string, error) {
+	sql := `SELECT u.team_name FROM pull_requests p
+		JOIN users u ON u.user_id = p.author_id
+	WHERE p.pull_request_id = $1`
+	var teamName string
+	if err := s.db.QueryRow(ctx, sql, prId).Scan(&teamName); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("postgres.GetPullRequestTeamName failed to query row: %w", err)
 	} else if errors.Is(err, pgx.ErrNoRows) {
-		return nil, ErrPullRequestNotFound
+		return "", ErrPullRequestNotFound
 	}
-	return &pr, nil
+	return teamName, nil
+}
+
+// replaceInactiveReviewers swaps the given reviewers on open pull requests for
+// random active members of the author's team, dropping them when nobody is
+// left. Every replacement is recorded as a deactivated_replaced event.
+func (s *Storage) replaceInactiveReviewers(
+	ctx context.Context,
+	tx pgx.Tx,
+	userIds []string,
+	actorId, reason string,
+) ([]api.Reassignment, error) {
+	const op = "postgres.replaceInactiveReviewers"
+	rows, err := tx.Query(ctx,
+		`SELECT pull_request_id, author_id, assigned_reviewers FROM pull_requests
+		WHERE status = 'OPEN' AND assigned_reviewers && $1
+		ORDER BY pull_request_id
+		FOR UPDATE`,
+		userIds)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query pull requests: %w", op, err)
+	}
+	prs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.PullRequest, error) {
+		var pr api.PullRequest
+		return pr, row.Scan(&pr.PullRequestId, &pr.AuthorId, &pr.AssignedReviewers)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect pull requests: %w", op, err)
+	}
+
+	reassignments := []api.Reassignment{}
+	for _, pr := range prs {
+		replaced := len(reassignments)
+		reviewers := make([]string, 0, len(pr.AssignedReviewers))
+		tabu := append(slices.Clone(pr.AssignedReviewers), pr.AuthorId)
+		teamName, err := s.GetTeamNameByUserId(ctx, tx, pr.AuthorId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reviewer := range pr.AssignedReviewers {
+			if !slices.Contains(userIds, reviewer) {
+				reviewers = append(reviewers, reviewer)
+				continue
+			}
+
+			candidate, err := s.GetReviewers(ctx, tx, teamName, tabu, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			r := api.Reassignment{PullRequestId: pr.PullRequestId, OldUserId: reviewer}
+			if len(candidate) > 0 {
+				r.ReplacedBy = &candidate[0]
+				reviewers = append(reviewers, candidate[0])
+				tabu = append(tabu, candidate[0])
+			}
+			reassignments = append(reassignments, r)
+		}
+
+		_, err = tx.Exec(ctx,
+			"UPDATE pull_requests SET assigned_reviewers = $1 WHERE pull_request_id = $2",
+			reviewers, pr.PullRequestId)
+		if err != nil {
+			return nil, fmt.Errorf("%v failed to update reviewers: %w", op, err)
+		}
+
+		for _, r := range reassignments[replaced:] {
+			err = s.addEvent(ctx, tx, api.AssignmentEvent{
+				PullRequestId: r.PullRequestId,
+				EventType:     api.DeactivatedReplaced,
+				ActorId:       &actorId,
+				OldUserId:     &r.OldUserId,
+				NewUserId:     r.ReplacedBy,
+				Reviewers:     reviewers,
+				Reason:        &reason,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return reassignments, nil
 }
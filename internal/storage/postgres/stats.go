@@ -0,0 +1,412 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// statsWindow selects pull requests created or merged in [$1, $2) together
+// with the team of the author and the number of reviewer replacements.
+const statsWindow = `WITH window_prs AS (
+		SELECT
+			p.pull_request_id,
+			p.author_id,
+			p.status,
+			COALESCE(p.assigned_reviewers, '{}') AS assigned_reviewers,
+			u.team_name,
+			(
+				SELECT COUNT(*)
+				FROM assignment_events e
+				WHERE e.pull_request_id = p.pull_request_id
+					AND e.event_type IN ('reassigned', 'declined', 'deactivated_replaced')
+			) AS reassignment_count
+		FROM pull_requests p
+			JOIN users u ON u.user_id = p.author_id
+		WHERE ($1::timestamp IS NULL AND $2::timestamp IS NULL)
+			OR (p.createdAt >= COALESCE($1, '-infinity') AND p.createdAt < COALESCE($2, 'infinity'))
+			OR (p.mergedAt >= COALESCE($1, '-infinity') AND p.mergedAt < COALESCE($2, 'infinity'))
+	)
+	`
+
+// GetUsersStats aggregates pull requests of the window by reviewer, team
+// of the author, author and pull request. Only the page limit of pull
+// requests with the most reviewer replacements is returned.
+func (s *Storage) GetUsersStats(ctx context.Context, params api.GetUsersStatsParams) (*api.AssignmentCountStat, error) {
+	const op = "postgres.GetUsersStats"
+
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, ErrInvalidListParams
+	}
+
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	from, to := utc(params.From), utc(params.To)
+	stat := &api.AssignmentCountStat{From: params.From, To: params.To}
+
+	reviewers, err := tx.Query(ctx, statsWindow+`SELECT
+		r.user_id,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE w.status = 'OPEN'),
+		COUNT(*) FILTER (WHERE w.status = 'MERGED')
+	FROM window_prs w, unnest(w.assigned_reviewers) AS r(user_id)
+	GROUP BY r.user_id
+	ORDER BY 2 DESC, r.user_id`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query reviewers: %w", op, err)
+	}
+	stat.Stats, err = pgx.CollectRows(reviewers, func(row pgx.CollectableRow) (api.AssignmentCount, error) {
+		var ac api.AssignmentCount
+		return ac, row.Scan(&ac.UserId, &ac.AssignmentCount, &ac.OpenCount, &ac.MergedCount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect reviewers: %w", op, err)
+	}
+
+	teams, err := tx.Query(ctx, statsWindow+`SELECT
+		team_name,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE status = 'OPEN'),
+		COUNT(*) FILTER (WHERE status = 'MERGED'),
+		SUM(reassignment_count)::int
+	FROM window_prs
+	GROUP BY team_name
+	ORDER BY team_name`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query teams: %w", op, err)
+	}
+	stat.Teams, err = pgx.CollectRows(teams, func(row pgx.CollectableRow) (api.TeamStat, error) {
+		var ts api.TeamStat
+		return ts, row.Scan(&ts.TeamName, &ts.PullRequestCount, &ts.OpenCount, &ts.MergedCount, &ts.ReassignmentCount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect teams: %w", op, err)
+	}
+
+	authors, err := tx.Query(ctx, statsWindow+`SELECT
+		author_id,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE status = 'OPEN'),
+		COUNT(*) FILTER (WHERE status = 'MERGED')
+	FROM window_prs
+	GROUP BY author_id
+	ORDER BY 2 DESC, author_id`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query authors: %w", op, err)
+	}
+	stat.Authors, err = pgx.CollectRows(authors, func(row pgx.CollectableRow) (api.AuthorStat, error) {
+		var as api.AuthorStat
+		return as, row.Scan(&as.UserId, &as.PullRequestCount, &as.OpenCount, &as.MergedCount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect authors: %w", op, err)
+	}
+
+	prs, err := tx.Query(ctx, statsWindow+`SELECT
+		pull_request_id,
+		author_id,
+		status,
+		cardinality(assigned_reviewers),
+		reassignment_count
+	FROM window_prs
+	ORDER BY reassignment_count DESC, pull_request_id
+	LIMIT $3`, from, to, pageLimit(params.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query pull requests: %w", op, err)
+	}
+	stat.PullRequests, err = pgx.CollectRows(prs, func(row pgx.CollectableRow) (api.PullRequestStat, error) {
+		var ps api.PullRequestStat
+		return ps, row.Scan(&ps.PullRequestId, &ps.AuthorId, &ps.Status, &ps.ReviewerCount, &ps.ReassignmentCount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect pull requests: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return stat, nil
+}
+
+// mergeWindow selects pull requests merged in [$1, $2) whose author is in
+// the team $3 together with the week of the merge and the time it took.
+const mergeWindow = `WITH merged_prs AS (
+		SELECT
+			COALESCE(p.assigned_reviewers, '{}') AS assigned_reviewers,
+			u.team_name,
+			date_trunc('week', p.mergedAt) AS week,
+			EXTRACT(EPOCH FROM p.mergedAt - p.createdAt)::float8 AS seconds
+		FROM pull_requests p
+			JOIN users u ON u.user_id = p.author_id
+		WHERE p.mergedAt IS NOT NULL
+			AND p.mergedAt >= COALESCE($1, '-infinity')
+			AND p.mergedAt < COALESCE($2, 'infinity')
+			AND ($3::text IS NULL OR u.team_name = $3)
+	)
+	`
+
+// GetTimeToMerge returns the median and the 90th percentile of the time from
+// creation to merge by week for teams of authors and for reviewers.
+func (s *Storage) GetTimeToMerge(ctx context.Context, params api.GetStatsTimeToMergeParams) (*api.TimeToMergeStat, error) {
+	const op = "postgres.GetTimeToMerge"
+
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, ErrInvalidListParams
+	}
+
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	from, to := utc(params.From), utc(params.To)
+	stat := &api.TimeToMergeStat{From: params.From, To: params.To}
+
+	teams, err := tx.Query(ctx, mergeWindow+`SELECT
+		team_name,
+		week,
+		COUNT(*),
+		round(percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds))::int,
+		round(percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds))::int
+	FROM merged_prs
+	GROUP BY team_name, week
+	ORDER BY team_name, week`, from, to, params.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query teams: %w", op, err)
+	}
+	stat.Teams, err = pgx.CollectRows(teams, func(row pgx.CollectableRow) (api.TeamMergeTime, error) {
+		var tm api.TeamMergeTime
+		return tm, row.Scan(&tm.TeamName, &tm.Week, &tm.MergedCount, &tm.MedianSeconds, &tm.P90Seconds)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect teams: %w", op, err)
+	}
+
+	reviewers, err := tx.Query(ctx, mergeWindow+`SELECT
+		r.user_id,
+		m.week,
+		COUNT(*),
+		round(percentile_cont(0.5) WITHIN GROUP (ORDER BY m.seconds))::int,
+		round(percentile_cont(0.9) WITHIN GROUP (ORDER BY m.seconds))::int
+	FROM merged_prs m, unnest(m.assigned_reviewers) AS r(user_id)
+	GROUP BY r.user_id, m.week
+	ORDER BY r.user_id, m.week`, from, to, params.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query reviewers: %w", op, err)
+	}
+	stat.Reviewers, err = pgx.CollectRows(reviewers, func(row pgx.CollectableRow) (api.ReviewerMergeTime, error) {
+		var rm api.ReviewerMergeTime
+		return rm, row.Scan(&rm.UserId, &rm.Week, &rm.MergedCount, &rm.MedianSeconds, &rm.P90Seconds)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect reviewers: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return stat, nil
+}
+
+// GetFairness returns the distribution of assignments to pull requests created
+// in the window among active members of every team.
+func (s *Storage) GetFairness(ctx context.Context, params api.GetStatsFairnessParams) (*api.FairnessStat, error) {
+	const op = "postgres.GetFairness"
+
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, ErrInvalidListParams
+	}
+
+	sql := `SELECT u.team_name, u.user_id, COUNT(p.pull_request_id)::int
+	FROM users u
+		LEFT JOIN pull_requests p ON u.user_id = ANY(p.assigned_reviewers)
+			AND p.createdAt >= COALESCE($1, '-infinity')
+			AND p.createdAt < COALESCE($2, 'infinity')
+	WHERE u.is_active AND ($3::text IS NULL OR u.team_name = $3)
+	GROUP BY u.team_name, u.user_id
+	ORDER BY u.team_name, u.user_id`
+	rows, err := s.db.Query(ctx, sql, utc(params.From), utc(params.To), params.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	type memberLoad struct {
+		teamName string
+		load     api.MemberLoad
+	}
+	loads, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (memberLoad, error) {
+		var ml memberLoad
+		return ml, row.Scan(&ml.teamName, &ml.load.UserId, &ml.load.AssignmentCount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	stat := &api.FairnessStat{From: params.From, To: params.To, Teams: []api.TeamFairness{}}
+	for i := 0; i < len(loads); {
+		j := i
+		var members []api.MemberLoad
+		for ; j < len(loads) && loads[j].teamName == loads[i].teamName; j++ {
+			members = append(members, loads[j].load)
+		}
+		stat.Teams = append(stat.Teams, teamFairness(loads[i].teamName, members))
+		i = j
+	}
+
+	return stat, nil
+}
+
+// teamFairness computes the spread of assignment counts of a team with
+// at least one member.
+func teamFairness(teamName string, members []api.MemberLoad) api.TeamFairness {
+	tf := api.TeamFairness{
+		TeamName:    teamName,
+		MemberCount: len(members),
+		Members:     members,
+		Min:         math.MaxInt,
+		OverMean:    []string{},
+		UnderMean:   []string{},
+	}
+
+	counts := make([]int, 0, len(members))
+	for _, m := range members {
+		counts = append(counts, m.AssignmentCount)
+		tf.AssignmentCount += m.AssignmentCount
+		tf.Min = min(tf.Min, m.AssignmentCount)
+		tf.Max = max(tf.Max, m.AssignmentCount)
+	}
+
+	n := float64(len(members))
+	mean := float64(tf.AssignmentCount) / n
+	var variance float64
+	for _, m := range members {
+		// Integer comparison keeps members exactly at the mean in neither list.
+		switch total := m.AssignmentCount * len(members); {
+		case total > tf.AssignmentCount:
+			tf.OverMean = append(tf.OverMean, m.UserId)
+		case total < tf.AssignmentCount:
+			tf.UnderMean = append(tf.UnderMean, m.UserId)
+		}
+		d := float64(m.AssignmentCount) - mean
+		variance += d * d / n
+	}
+
+	// Gini coefficient over counts sorted ascending:
+	// G = 2 * sum(i * x_i) / (n * sum(x)) - (n + 1) / n.
+	var gini float64
+	if tf.AssignmentCount > 0 {
+		slices.Sort(counts)
+		var weighted float64
+		for i, c := range counts {
+			weighted += float64(i+1) * float64(c)
+		}
+		gini = 2*weighted/(n*float64(tf.AssignmentCount)) - (n+1)/n
+	}
+
+	tf.Mean = round(mean)
+	tf.Stddev = round(math.Sqrt(variance))
+	tf.Gini = round(gini)
+	return tf
+}
+
+// round keeps three decimal places of a statistic.
+func round(x float64) float32 {
+	return float32(math.Round(x*1000) / 1000)
+}
+
+// CountOpenPullRequests returns the number of open pull requests by the team
+// of the author, teams without open pull requests included.
+func (s *Storage) CountOpenPullRequests(ctx context.Context) (map[string]int, error) {
+	const op = "postgres.CountOpenPullRequests"
+	rows, err := s.db.Query(ctx, `SELECT u.team_name, COUNT(p.pull_request_id)::int
+	FROM users u
+		LEFT JOIN pull_requests p ON p.author_id = u.user_id AND p.status = 'OPEN'
+	GROUP BY u.team_name`)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	counts := map[string]int{}
+	var (
+		teamName string
+		count    int
+	)
+	_, err = pgx.ForEachRow(rows, []any{&teamName, &count}, func() error {
+		counts[teamName] = count
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return counts, nil
+}
+
+// SaveStatsSnapshot stores per-user and per-team aggregates of pull requests
+// created or merged during the UTC day and returns the number of rows added.
+// A day that already has rows is skipped as a whole, so subjects appearing
+// later are not appended to a stored day.
+func (s *Storage) SaveStatsSnapshot(ctx context.Context, day time.Time) (int64, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	sql := statsWindow + `INSERT INTO stats_snapshots (
+		snapshot_date, subject_type, subject_id, team_name,
+		pull_request_count, open_count, merged_count, reassignment_count
+	)
+	SELECT
+		$1::date,
+		'user'::snapshot_subject,
+		r.user_id,
+		u.team_name,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE w.status = 'OPEN'),
+		COUNT(*) FILTER (WHERE w.status = 'MERGED'),
+		SUM(w.reassignment_count)
+	FROM window_prs w
+		CROSS JOIN LATERAL unnest(w.assigned_reviewers) AS r(user_id)
+		LEFT JOIN users u ON u.user_id = r.user_id
+	WHERE NOT EXISTS (SELECT 1 FROM stats_snapshots WHERE snapshot_date = $1::date)
+	GROUP BY r.user_id, u.team_name
+	UNION ALL
+	SELECT
+		$1::date,
+		'team'::snapshot_subject,
+		team_name,
+		team_name,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE status = 'OPEN'),
+		COUNT(*) FILTER (WHERE status = 'MERGED'),
+		SUM(reassignment_count)
+	FROM window_prs
+	WHERE NOT EXISTS (SELECT 1 FROM stats_snapshots WHERE snapshot_date = $1::date)
+	GROUP BY team_name
+	ON CONFLICT DO NOTHING`
+	tag, err := s.db.Exec(ctx, sql, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("postgres.SaveStatsSnapshot failed to execute insert: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// LastStatsSnapshotDate returns the latest UTC day with a stored snapshot,
+// or nil when there is none.
+func (s *Storage) LastStatsSnapshotDate(ctx context.Context) (*time.Time, error) {
+	var day *time.Time
+	if err := s.db.QueryRow(ctx, `SELECT MAX(snapshot_date) FROM stats_snapshots`).Scan(&day); err != nil {
+		return nil, fmt.Errorf("postgres.LastStatsSnapshotDate failed to query row: %w", err)
+	}
+	return day, nil
+}
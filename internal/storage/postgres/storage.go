@@ -3,24 +3,31 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type DB interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
 	Query(ctx context.Context, sql string, arg ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 type Storage struct {
 	db DB
+
+	// transports limits the queued notifications, nil queues every transport.
+	transports []string
 }
 
 var (
 	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("user already exists")
 
 	ErrTeamNotFound = errors.New("team not found")
 	ErrTeamExists   = errors.New("team already exists")
@@ -30,6 +37,16 @@ var (
 	ErrReassignMergedPullRequest = errors.New("cannot reassign on merge pull request")
 	ErrUserNotAReviewer          = errors.New("user is not a reviewer of pull request")
 	ErrNoCandidate               = errors.New("no active replacment candidadte in team")
+
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+
+	ErrAccountNotFound = errors.New("code host account not found")
+
+	ErrContactNotFound = errors.New("notification contact not found")
+
+	ErrInvalidCursor     = errors.New("invalid page cursor")
+	ErrInvalidListParams = errors.New("invalid list parameters")
 )
 
 func NewWithPool(p *pgxpool.Pool) *Storage {
@@ -74,3 +91,37 @@ func Rollback(ctx context.Context, tx pgx.Tx) {
 			"error", err)
 	}
 }
+
+// beginSnapshot starts a read-only transaction whose queries all see the
+// same snapshot. Inside a transaction it falls back to a savepoint.
+func (s *Storage) beginSnapshot(ctx context.Context) (pgx.Tx, error) {
+	if pool, ok := s.db.(*pgxpool.Pool); ok {
+		return pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	}
+	return s.db.Begin(ctx)
+}
+
+// Ping checks that the database accepts queries.
+func (s *Storage) Ping(ctx context.Context) error {
+	if pool, ok := s.db.(*pgxpool.Pool); ok {
+		return pool.Ping(ctx)
+	}
+	_, err := s.db.Exec(ctx, "SELECT 1")
+	return err
+}
+
+// MigrationVersion returns the schema version recorded by golang-migrate,
+// zero before the first migration.
+func (s *Storage) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := s.db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("postgres.MigrationVersion failed to query row: %w", err)
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EventsChannel is the LISTEN/NOTIFY channel carrying ids of committed
+// assignment events.
+const EventsChannel = "assignment_events"
+
+const assignmentEventSelect = `SELECT
+		e.event_id,
+		e.pull_request_id,
+		e.event_type,
+		e.actor_id,
+		e.old_user_id,
+		e.new_user_id,
+		e.reviewers,
+		e.reason,
+		e.createdAt
+	FROM assignment_events e`
+
+// EventFilter narrows the event stream to events involving the user or
+// members of the team. Empty fields do not filter.
+type EventFilter struct {
+	UserId   string
+	TeamName string
+}
+
+// notifyEvent wakes up event streams of every replica once the
+// transaction of the event commits.
+func (s *Storage) notifyEvent(ctx context.Context, tx pgx.Tx, eventId int64) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, EventsChannel, strconv.FormatInt(eventId, 10)); err != nil {
+		return fmt.Errorf("postgres.notifyEvent failed to notify: %w", err)
+	}
+	return nil
+}
+
+// CheckEventFilter returns ErrUserNotFound or ErrTeamNotFound when
+// the filter refers to a missing user or team.
+func (s *Storage) CheckEventFilter(ctx context.Context, filter EventFilter) error {
+	const op = "postgres.CheckEventFilter"
+	if filter.UserId != "" {
+		var ok bool
+		err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1)`, filter.UserId).Scan(&ok)
+		if err != nil {
+			return fmt.Errorf("%v failed to query user: %w", op, err)
+		} else if !ok {
+			return ErrUserNotFound
+		}
+	}
+	if filter.TeamName != "" {
+		var ok bool
+		err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE team_name = $1)`, filter.TeamName).Scan(&ok)
+		if err != nil {
+			return fmt.Errorf("%v failed to query team: %w", op, err)
+		} else if !ok {
+			return ErrTeamNotFound
+		}
+	}
+	return nil
+}
+
+// committedEvents restricts events to transactions below the snapshot
+// horizon: every transaction with a smaller id has finished, so no event
+// can appear before the ones already streamed. Events of the current
+// transaction are visible to itself.
+const committedEvents = `(e.txid < pg_snapshot_xmin(pg_current_snapshot())
+		OR e.txid = pg_current_xact_id_if_assigned())`
+
+// LastEventId returns the id of the latest event in stream order or 0
+// when there are none.
+func (s *Storage) LastEventId(ctx context.Context) (int64, error) {
+	sql := `SELECT COALESCE((
+		SELECT e.event_id FROM assignment_events e
+		WHERE ` + committedEvents + `
+		ORDER BY e.txid DESC, e.event_id DESC
+		LIMIT 1
+	), 0)`
+	var id int64
+	if err := s.db.QueryRow(ctx, sql).Scan(&id); err != nil {
+		return 0, fmt.Errorf("postgres.LastEventId failed to query row: %w", err)
+	}
+	return id, nil
+}
+
+// ListEventsAfter returns up to limit events following the event with
+// afterId in stream order. Events of unknown afterId are listed by id.
+func (s *Storage) ListEventsAfter(
+	ctx context.Context,
+	afterId int64,
+	filter EventFilter,
+	limit int,
+) ([]api.AssignmentEvent, error) {
+	const op = "postgres.ListEventsAfter"
+	sql := `WITH last_event AS (
+		SELECT txid, event_id FROM assignment_events WHERE event_id = $1
+	)
+	` + assignmentEventSelect + `
+	WHERE ` + committedEvents + `
+		AND CASE WHEN EXISTS (SELECT 1 FROM last_event)
+			THEN (e.txid, e.event_id) > (SELECT txid, event_id FROM last_event)
+			ELSE e.event_id > $1
+		END
+		AND ($2 = '' OR $2 IN (e.actor_id, e.old_user_id, e.new_user_id) OR e.reviewers @> ARRAY[$2])
+		AND ($3 = '' OR EXISTS (
+			SELECT 1
+			FROM users u
+				JOIN pull_requests p ON p.pull_request_id = e.pull_request_id
+			WHERE u.team_name = $3
+				AND (u.user_id IN (p.author_id, e.old_user_id, e.new_user_id) OR u.user_id = ANY(e.reviewers))
+		))
+	ORDER BY e.txid, e.event_id
+	LIMIT $4`
+	rows, err := s.db.Query(ctx, sql, afterId, filter.UserId, filter.TeamName, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.AssignmentEvent, error) {
+		return scanAssignmentEvent(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return events, nil
+}
+
+// ListenEvents calls notify with the id of every assignment event
+// committed while listening. It holds a pool connection until ctx is
+// done or the connection fails.
+func (s *Storage) ListenEvents(ctx context.Context, notify func(eventId int64)) error {
+	const op = "postgres.ListenEvents"
+	pool, ok := s.db.(*pgxpool.Pool)
+	if !ok {
+		return errors.New(op + " requires a connection pool")
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("%v failed to acquire connection: %w", op, err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+EventsChannel); err != nil {
+		return fmt.Errorf("%v failed to listen: %w", op, err)
+	}
+	defer func() {
+		if !conn.Conn().IsClosed() {
+			_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+		}
+	}()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("%v failed to wait for notification: %w", op, err)
+		}
+		id, err := strconv.ParseInt(n.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		notify(id)
+	}
+}
+
+func scanAssignmentEvent(row pgx.Row) (api.AssignmentEvent, error) {
+	var e api.AssignmentEvent
+	return e, row.Scan(
+		&e.EventId,
+		&e.PullRequestId,
+		&e.EventType,
+		&e.ActorId,
+		&e.OldUserId,
+		&e.NewUserId,
+		&e.Reviewers,
+		&e.Reason,
+		&e.CreatedAt,
+	)
+}
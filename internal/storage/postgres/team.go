@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"avito-trainee-task/internal/api"
 
@@ -13,7 +14,7 @@ import (
 func (s *Storage) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
 	const op = "postgres.GetTeam"
 	rows, err := s.db.Query(ctx,
-		`SELECT user_id, username, is_active FROM users 
+		`SELECT user_id, username, is_active, role FROM users 
 		WHERE team_name = $1`,
 		teamName)
 	if err != nil {
@@ -26,6 +27,7 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (*api.Team, erro
 			&m.UserId,
 			&m.Username,
 			&m.IsActive,
+			&m.Role,
 		)
 	})
 	if err != nil {
@@ -42,6 +44,60 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (*api.Team, erro
 	}, nil
 }
 
+func (s *Storage) ListTeams(ctx context.Context, params api.GetTeamListParams) (*api.TeamList, error) {
+	const op = "postgres.ListTeams"
+
+	var after *string
+	if params.Cursor != nil {
+		keys, err := decodeCursor(*params.Cursor, 1)
+		if err != nil {
+			return nil, err
+		}
+		after = &keys[0]
+	}
+
+	limit := pageLimit(params.Limit)
+	sql := `SELECT
+		u.team_name,
+		COUNT(*) AS member_count,
+		COUNT(*) FILTER (WHERE u.is_active) AS active_member_count,
+		(SELECT COUNT(*) FROM pull_requests p
+			JOIN users a ON a.user_id = p.author_id
+		WHERE a.team_name = u.team_name AND p.status = 'OPEN') AS open_pull_request_count
+	FROM users u
+	WHERE ($1::text IS NULL OR u.team_name > $1)
+	GROUP BY u.team_name
+	ORDER BY u.team_name
+	LIMIT $2`
+	rows, err := s.db.Query(ctx, sql, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	teams, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.TeamSummary, error) {
+		var t api.TeamSummary
+		return t, row.Scan(
+			&t.TeamName,
+			&t.MemberCount,
+			&t.ActiveMemberCount,
+			&t.OpenPullRequestCount,
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	teams, next := nextPage(teams, limit, func(t api.TeamSummary) []string {
+		return []string{t.TeamName}
+	})
+	return &api.TeamList{
+		Teams:      teams,
+		NextCursor: next,
+	}, nil
+}
+
+// AddTeam creates the team, existing users are moved into it. A member
+// without a role keeps the stored one, new users become members.
 func (s *Storage) AddTeam(ctx context.Context, team api.Team) (*api.Team, error) {
 	const op = "postgres.AddTeam"
 	tx, err := s.db.Begin(ctx)
@@ -57,19 +113,21 @@ func (s *Storage) AddTeam(ctx context.Context, team api.Team) (*api.Team, error)
 		return nil, ErrTeamExists
 	}
 
-	sql := `INSERT INTO users (user_id, username, team_name, is_active)
-	VALUES ($1, $2, $3, $4)
+	sql := `INSERT INTO users (user_id, username, team_name, is_active, role)
+	VALUES ($1, $2, $3, $4, COALESCE($5::user_role, 'member'))
 	ON CONFLICT (user_id)
-	DO UPDATE SET 
-        team_name = EXCLUDED.team_name
-	RETURNING user_id, username, is_active`
+	DO UPDATE SET
+		team_name = EXCLUDED.team_name,
+		role = COALESCE($5::user_role, users.role)
+	RETURNING user_id, username, is_active, role`
 	var members []api.TeamMember
 	for _, m := range team.Members {
 		var member api.TeamMember
-		err := tx.QueryRow(ctx, sql, m.UserId, m.Username, team.TeamName, m.IsActive).Scan(
+		err := tx.QueryRow(ctx, sql, m.UserId, m.Username, team.TeamName, m.IsActive, m.Role).Scan(
 			&member.UserId,
 			&member.Username,
 			&member.IsActive,
+			&member.Role,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%v failed to query row: %w", op, err)
@@ -96,3 +154,152 @@ func (s *Storage) IsTeamExists(ctx context.Context, tx pgx.Tx, teamName string)
 	}
 	return ok, nil
 }
+
+// IsTeamLead reports whether the user is an active lead of the team. It
+// returns ErrTeamNotFound for an unknown team.
+func (s *Storage) IsTeamLead(ctx context.Context, userId, teamName string) (bool, error) {
+	sql := `SELECT
+		EXISTS(SELECT 1 FROM users WHERE team_name = $2),
+		EXISTS(SELECT 1 FROM users WHERE user_id = $1 AND team_name = $2 AND role = 'lead' AND is_active)`
+	var exists, ok bool
+	err := s.db.QueryRow(ctx, sql, userId, teamName).Scan(&exists, &ok)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ok, fmt.Errorf("postgres.IsTeamLead failed to query row: %w", err)
+	} else if !exists {
+		return false, ErrTeamNotFound
+	}
+	return ok, nil
+}
+
+// GetUserTeams returns the teams of the existing users by user_id.
+func (s *Storage) GetUserTeams(ctx context.Context, userIds []string) (map[string]string, error) {
+	rows, err := s.db.Query(ctx, "SELECT user_id, team_name FROM users WHERE user_id = ANY($1)", userIds)
+	if err != nil {
+		return nil, fmt.Errorf("postgres.GetUserTeams failed to query rows: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make(map[string]string)
+	for rows.Next() {
+		var userId, teamName string
+		if err := rows.Scan(&userId, &teamName); err != nil {
+			return nil, fmt.Errorf("postgres.GetUserTeams failed to scan row: %w", err)
+		}
+		teams[userId] = teamName
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres.GetUserTeams failed to read rows: %w", err)
+	}
+	return teams, nil
+}
+
+// HasLead reports whether any team has an active lead.
+func (s *Storage) HasLead(ctx context.Context) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM users WHERE role = 'lead' AND is_active)`
+	var ok bool
+	if err := s.db.QueryRow(ctx, sql).Scan(&ok); err != nil {
+		return false, fmt.Errorf("postgres.HasLead failed to query row: %w", err)
+	}
+	return ok, nil
+}
+
+// IsLead reports whether the user is an active lead of any team.
+func (s *Storage) IsLead(ctx context.Context, userId string) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1 AND role = 'lead' AND is_active)`
+	var ok bool
+	if err := s.db.QueryRow(ctx, sql, userId).Scan(&ok); err != nil {
+		return false, fmt.Errorf("postgres.IsLead failed to query row: %w", err)
+	}
+	return ok, nil
+}
+
+func (s *Storage) DeactivateTeamMembers(
+	ctx context.Context,
+	teamName string,
+	userIds []string,
+	actorId string,
+) (*api.DeactivationResult, error) {
+	const op = "postgres.DeactivateTeamMembers"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if exists, err := s.IsTeamExists(ctx, tx, teamName); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrTeamNotFound
+	}
+
+	rows, err := tx.Query(ctx,
+		`UPDATE users SET is_active = false
+		WHERE team_name = $1 AND user_id = ANY($2)
+		RETURNING user_id`,
+		teamName, userIds)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to deactivate users: %w", op, err)
+	}
+	deactivated, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect deactivated users: %w", op, err)
+	}
+
+	reassignments, err := s.replaceInactiveReviewers(ctx, tx, deactivated, actorId, "team deactivation")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &api.DeactivationResult{
+		TeamName:           teamName,
+		DeactivatedUserIds: deactivated,
+		Reassignments:      reassignments,
+	}, nil
+}
+
+// SetTeamMembers moves the added users into the team and the removed
+// members of the team into the fallback team.
+func (s *Storage) SetTeamMembers(
+	ctx context.Context,
+	teamName string,
+	add, remove []string,
+	fallbackTeam string,
+) error {
+	const op = "postgres.SetTeamMembers"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	if len(add) > 0 {
+		tag, err := tx.Exec(ctx,
+			"UPDATE users SET team_name = $1 WHERE user_id = ANY($2)",
+			teamName, add)
+		if err != nil {
+			return fmt.Errorf("%v failed to add members: %w", op, err)
+		}
+
+		if unique := slices.Compact(slices.Sorted(slices.Values(add))); tag.RowsAffected() != int64(len(unique)) {
+			return ErrUserNotFound
+		}
+	}
+
+	if len(remove) > 0 {
+		_, err := tx.Exec(ctx,
+			"UPDATE users SET team_name = $1 WHERE team_name = $2 AND user_id = ANY($3)",
+			fallbackTeam, teamName, remove)
+		if err != nil {
+			return fmt.Errorf("%v failed to remove members: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+	return nil
+}
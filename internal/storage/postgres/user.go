@@ -4,41 +4,271 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"avito-trainee-task/internal/api"
 
 	"github.com/jackc/pgx/v5"
 )
 
-func (s *Storage) GetReview(ctx context.Context, userId string) ([]*api.PullRequestShort, error) {
+const userDetailsSelect = `SELECT
+		u.user_id,
+		u.username,
+		u.team_name,
+		u.is_active,
+		(SELECT COUNT(*) FROM pull_requests p
+		WHERE p.status = 'OPEN' AND u.user_id = ANY(p.assigned_reviewers)) AS open_review_count
+	FROM users u`
+
+func (s *Storage) GetUser(ctx context.Context, userId string) (*api.UserDetails, error) {
+	sql := userDetailsSelect + `
+	WHERE u.user_id = $1`
+
+	user, err := scanUserDetails(s.db.QueryRow(ctx, sql, userId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.GetUser failed to query row: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (s *Storage) ListUsers(ctx context.Context, params api.GetUsersListParams) (*api.UserList, error) {
+	const op = "postgres.ListUsers"
+
+	var after *string
+	if params.Cursor != nil {
+		keys, err := decodeCursor(*params.Cursor, 1)
+		if err != nil {
+			return nil, err
+		}
+		after = &keys[0]
+	}
+
+	var prefix *string
+	if params.UsernamePrefix != nil {
+		p := likePrefix(*params.UsernamePrefix)
+		prefix = &p
+	}
+
+	limit := pageLimit(params.Limit)
+	sql := userDetailsSelect + `
+	WHERE ($1::text IS NULL OR u.team_name = $1)
+		AND ($2::boolean IS NULL OR u.is_active = $2)
+		AND ($3::text IS NULL OR u.username LIKE $3)
+		AND ($4::text IS NULL OR u.user_id > $4)
+	ORDER BY u.user_id
+	LIMIT $5`
+	rows, err := s.db.Query(ctx, sql, params.TeamName, params.IsActive, prefix, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	users, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.UserDetails, error) {
+		return scanUserDetails(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	users, next := nextPage(users, limit, func(u api.UserDetails) []string {
+		return []string{u.UserId}
+	})
+	return &api.UserList{
+		Users:      users,
+		NextCursor: next,
+	}, nil
+}
+
+func scanUserDetails(row pgx.Row) (api.UserDetails, error) {
+	var u api.UserDetails
+	return u, row.Scan(
+		&u.UserId,
+		&u.Username,
+		&u.TeamName,
+		&u.IsActive,
+		&u.OpenReviewCount,
+	)
+}
+
+type UserFilter struct {
+	UserId   *string
+	Username *string
+	IsActive *bool
+}
+
+// FindUsers returns an offset page of users matching the filter together
+// with the total number of matches.
+func (s *Storage) FindUsers(ctx context.Context, filter UserFilter, offset, limit int) ([]api.User, int, error) {
+	const op = "postgres.FindUsers"
+	where := `WHERE ($1::text IS NULL OR user_id = $1)
+		AND ($2::text IS NULL OR username = $2)
+		AND ($3::boolean IS NULL OR is_active = $3)`
+
+	var total int
+	err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM users "+where,
+		filter.UserId, filter.Username, filter.IsActive).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%v failed to count users: %w", op, err)
+	}
+
+	sql := `SELECT user_id, username, team_name, is_active FROM users ` + where + `
+	ORDER BY user_id
+	OFFSET $4 LIMIT $5`
+	rows, err := s.db.Query(ctx, sql, filter.UserId, filter.Username, filter.IsActive, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	users, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.User, error) {
+		var u api.User
+		return u, row.Scan(&u.UserId, &u.Username, &u.TeamName, &u.IsActive)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	return users, total, nil
+}
+
+func (s *Storage) CreateUser(ctx context.Context, user api.User) (*api.User, error) {
+	sql := `INSERT INTO users (user_id, username, team_name, is_active)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (user_id) DO NOTHING
+	RETURNING user_id, username, team_name, is_active`
+
+	var created api.User
+	err := s.db.QueryRow(ctx, sql, user.UserId, user.Username, user.TeamName, user.IsActive).Scan(
+		&created.UserId,
+		&created.Username,
+		&created.TeamName,
+		&created.IsActive,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserExists
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.CreateUser failed to query row: %w", err)
+	}
+
+	return &created, nil
+}
+
+// UpdateUser replaces the user attributes. Deactivated users are replaced
+// on the open pull requests they review.
+func (s *Storage) UpdateUser(ctx context.Context, user api.User) (*api.User, error) {
+	const op = "postgres.UpdateUser"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	var wasActive bool
+	err = tx.QueryRow(ctx, "SELECT is_active FROM users WHERE user_id = $1 FOR UPDATE", user.UserId).Scan(&wasActive)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("%v failed to query user: %w", op, err)
+	}
+
+	sql := `UPDATE users SET username = $2, team_name = $3, is_active = $4
+	WHERE user_id = $1`
+	if _, err = tx.Exec(ctx, sql, user.UserId, user.Username, user.TeamName, user.IsActive); err != nil {
+		return nil, fmt.Errorf("%v failed to execute update: %w", op, err)
+	}
+
+	if wasActive && !user.IsActive {
+		if _, err = s.replaceInactiveReviewers(ctx, tx, []string{user.UserId}, "", "user deactivated"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// GetReview returns a page of pull requests assigned to the user ordered by
+// creation date, together with the total number of matching pull requests.
+func (s *Storage) GetReview(ctx context.Context, params api.GetUsersGetReviewParams) (*api.ReviewList, error) {
 	const op = "postgres.GetReview"
+
+	var status *api.GetUsersGetReviewParamsStatus
+	switch {
+	case params.Status == nil:
+		open := api.GetUsersGetReviewParamsStatusOPEN
+		status = &open
+	case *params.Status == api.GetUsersGetReviewParamsStatusOPEN,
+		*params.Status == api.GetUsersGetReviewParamsStatusMERGED:
+		status = params.Status
+	case *params.Status != api.GetUsersGetReviewParamsStatusALL:
+		return nil, ErrInvalidListParams
+	}
+
+	order := api.Desc
+	if params.Order != nil {
+		order = *params.Order
+	}
+	cmp, nullKey, err := timeOrder(order)
+	if err != nil {
+		return nil, err
+	}
+
+	var afterKey, afterId *string
+	if params.Cursor != nil {
+		keys, err := decodeCursor(*params.Cursor, 3)
+		if err != nil {
+			return nil, err
+		}
+		if keys[0] != string(order) || !isTimeKey(keys[1], nullKey) {
+			return nil, ErrInvalidCursor
+		}
+		afterKey, afterId = &keys[1], &keys[2]
+	}
+
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
 	}
 	defer Rollback(ctx, tx)
 
-	exists, err := s.IsUserExists(ctx, tx, userId)
+	exists, err := s.IsUserExists(ctx, tx, params.UserId)
 	if err != nil {
 		return nil, err
 	} else if !exists {
 		return nil, ErrUserNotFound
 	}
 
-	sql := `SELECT author_id, pull_request_id, pull_request_name, status FROM pull_requests
-	WHERE $1 = ANY(assigned_reviewers)`
-	rows, err := tx.Query(ctx, sql, userId)
+	where := `WHERE $1 = ANY(assigned_reviewers)
+		AND ($2::pr_status IS NULL OR status = $2)`
+
+	var total int
+	if err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM pull_requests "+where, params.UserId, status).Scan(&total); err != nil {
+		return nil, fmt.Errorf("%v failed to count pull requests: %w", op, err)
+	}
+
+	key := fmt.Sprintf("COALESCE(createdAt, '%s')", nullKey)
+	sql := fmt.Sprintf(`SELECT author_id, pull_request_id, pull_request_name, status, createdAt FROM pull_requests
+	%[1]s
+		AND ($3::timestamp IS NULL OR (%[2]s, pull_request_id) %[3]s ($3, $4))
+	ORDER BY %[2]s %[4]s, pull_request_id %[4]s
+	LIMIT $5`, where, key, cmp, order)
+	limit := pageLimit(params.Limit)
+	rows, err := tx.Query(ctx, sql, params.UserId, status, afterKey, afterId, limit+1)
 	if err != nil {
 		return nil, fmt.Errorf("%v failed to query pull requests: %w", op, err)
 	}
 
-	prs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*api.PullRequestShort, error) {
-		var pr api.PullRequestShort
-		err := row.Scan(&pr.AuthorId, &pr.PullRequestId, &pr.PullRequestName, &pr.Status)
-		if err != nil {
-			return nil, fmt.Errorf("%v failed to scan row: %w", op, err)
-		}
-		return &pr, nil
+	type review struct {
+		api.PullRequestShort
+		CreatedAt *time.Time
+	}
+	reviews, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (review, error) {
+		var r review
+		return r, row.Scan(&r.AuthorId, &r.PullRequestId, &r.PullRequestName, &r.Status, &r.CreatedAt)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
@@ -48,12 +278,26 @@ func (s *Storage) GetReview(ctx context.Context, userId string) ([]*api.PullRequ
 		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
 	}
 
-	return prs, nil
+	reviews, next := nextPage(reviews, limit, func(r review) []string {
+		return []string{string(order), timeKey(r.CreatedAt, nullKey), r.PullRequestId}
+	})
+	prs := make([]api.PullRequestShort, 0, len(reviews))
+	for _, r := range reviews {
+		prs = append(prs, r.PullRequestShort)
+	}
+
+	return &api.ReviewList{
+		UserId:       params.UserId,
+		PullRequests: prs,
+		Total:        total,
+		NextCursor:   next,
+	}, nil
 }
 
 func (s *Storage) SetIsActive(ctx context.Context, UserId string, isActive bool) (*api.User, error) {
 	const op = "postgres.SetIsActive"
-	sql := "UPDATE users SET is_active = $1 WHERE user_id = $2 RETURNING *"
+	sql := `UPDATE users SET is_active = $1 WHERE user_id = $2
+	RETURNING user_id, username, team_name, is_active`
 
 	var user api.User
 	err := s.db.QueryRow(ctx, sql, isActive, UserId).Scan(
@@ -72,35 +316,6 @@ func (s *Storage) SetIsActive(ctx context.Context, UserId string, isActive bool)
 	return &user, nil
 }
 
-func (s *Storage) GetUsersStats(ctx context.Context) (*api.AssignmentCountStat, error) {
-	const op = "postgres.GetStats"
-	sql := `
-	SELECT 
-		user_id, 
-		COUNT(*) as assignment_count 
-	FROM pull_requests, 
-    	unnest(assigned_reviewers) as user_id
-	GROUP BY user_id
-	ORDER BY assignment_count DESC`
-
-	rows, err := s.db.Query(ctx, sql)
-	if err != nil {
-		return nil, fmt.Errorf("%v failed to query: %w", op, err)
-	}
-
-	stat, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.AssignmentCount, error) {
-		var ac api.AssignmentCount
-		return ac, row.Scan(&ac.UserId, &ac.AssignmentCount)
-	})
-	if err != nil {
-		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
-	}
-
-	return &api.AssignmentCountStat{
-		Stats: stat,
-	}, nil
-}
-
 func (s *Storage) GetTeamNameByUserId(ctx context.Context, tx pgx.Tx, userId string) (string, error) {
 	sql := "SELECT team_name FROM users WHERE user_id = $1"
 	var authorTeam string
@@ -0,0 +1,302 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PendingDelivery is a webhook delivery claimed by the dispatcher.
+type PendingDelivery struct {
+	DeliveryId int64
+	Attempts   int
+	URL        string
+	Secret     string
+	Payload    api.WebhookPayload
+}
+
+const webhookDeliverySelect = `SELECT
+		d.delivery_id,
+		d.event_id,
+		d.subscription_id,
+		e.event_type,
+		d.status,
+		d.attempts,
+		d.next_attempt_at,
+		d.last_status_code,
+		d.last_error,
+		d.deliveredAt
+	FROM webhook_deliveries d
+		JOIN outbox_events e ON e.event_id = d.event_id`
+
+// publish writes the event to the outbox and schedules its delivery to every
+// matching subscription in the transaction of the state change.
+func (s *Storage) publish(ctx context.Context, tx pgx.Tx, eventType api.WebhookEventType, data any) error {
+	sql := `WITH event AS (
+		INSERT INTO outbox_events (event_type, payload)
+		VALUES ($1, $2)
+		RETURNING event_id
+	)
+	INSERT INTO webhook_deliveries (event_id, subscription_id)
+	SELECT event.event_id, s.subscription_id
+	FROM event, webhook_subscriptions s
+	WHERE cardinality(s.event_types) = 0 OR $1 = ANY(s.event_types)`
+	if _, err := tx.Exec(ctx, sql, eventType, data); err != nil {
+		return fmt.Errorf("postgres.publish failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) CreateWebhookSubscription(
+	ctx context.Context,
+	req api.PostWebhooksSubscribeJSONBody,
+) (*api.WebhookSubscription, error) {
+	eventTypes := []api.WebhookEventType{}
+	if req.EventTypes != nil {
+		eventTypes = *req.EventTypes
+	}
+
+	sql := `INSERT INTO webhook_subscriptions (url, secret, event_types)
+	VALUES ($1, $2, $3)
+	RETURNING subscription_id, url, event_types, createdAt`
+	sub, err := scanWebhookSubscription(s.db.QueryRow(ctx, sql, req.Url, req.Secret, eventTypes))
+	if err != nil {
+		return nil, fmt.Errorf("postgres.CreateWebhookSubscription failed to query row: %w", err)
+	}
+	return &sub, nil
+}
+
+func (s *Storage) ListWebhookSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error) {
+	const op = "postgres.ListWebhookSubscriptions"
+	rows, err := s.db.Query(ctx, `SELECT subscription_id, url, event_types, createdAt
+	FROM webhook_subscriptions
+	ORDER BY subscription_id`)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	subs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.WebhookSubscription, error) {
+		return scanWebhookSubscription(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return subs, nil
+}
+
+// DeleteWebhookSubscription removes the subscription together with
+// all of its deliveries.
+func (s *Storage) DeleteWebhookSubscription(ctx context.Context, subscriptionId int64) (*api.WebhookSubscription, error) {
+	sql := `DELETE FROM webhook_subscriptions WHERE subscription_id = $1
+	RETURNING subscription_id, url, event_types, createdAt`
+	sub, err := scanWebhookSubscription(s.db.QueryRow(ctx, sql, subscriptionId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSubscriptionNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.DeleteWebhookSubscription failed to query row: %w", err)
+	}
+	return &sub, nil
+}
+
+func (s *Storage) ListWebhookDeliveries(
+	ctx context.Context,
+	params api.GetWebhooksDeliveriesParams,
+) (*api.WebhookDeliveryList, error) {
+	const op = "postgres.ListWebhookDeliveries"
+
+	if params.Status != nil {
+		switch *params.Status {
+		case api.Pending, api.Delivered, api.Dead:
+		default:
+			return nil, ErrInvalidListParams
+		}
+	}
+
+	var after *int64
+	if params.Cursor != nil {
+		keys, err := decodeCursor(*params.Cursor, 1)
+		if err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseInt(keys[0], 10, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		after = &id
+	}
+
+	limit := pageLimit(params.Limit)
+	sql := webhookDeliverySelect + `
+	WHERE ($1::delivery_status IS NULL OR d.status = $1)
+		AND ($2::bigint IS NULL OR d.subscription_id = $2)
+		AND ($3::bigint IS NULL OR d.delivery_id < $3)
+	ORDER BY d.delivery_id DESC
+	LIMIT $4`
+	rows, err := s.db.Query(ctx, sql, params.Status, params.SubscriptionId, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (api.WebhookDelivery, error) {
+		return scanWebhookDelivery(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+
+	deliveries, next := nextPage(deliveries, limit, func(d api.WebhookDelivery) []string {
+		return []string{strconv.FormatInt(d.DeliveryId, 10)}
+	})
+	return &api.WebhookDeliveryList{
+		Deliveries: deliveries,
+		NextCursor: next,
+	}, nil
+}
+
+// RedeliverWebhook puts the delivery back to the queue with
+// the attempt counter reset.
+func (s *Storage) RedeliverWebhook(ctx context.Context, deliveryId int64) (*api.WebhookDelivery, error) {
+	const op = "postgres.RedeliverWebhook"
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to begin transaction: %w", op, err)
+	}
+	defer Rollback(ctx, tx)
+
+	tag, err := tx.Exec(ctx, `UPDATE webhook_deliveries
+	SET status = 'pending', attempts = 0, next_attempt_at = NOW(), deliveredAt = NULL
+	WHERE delivery_id = $1`, deliveryId)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to execute update: %w", op, err)
+	} else if tag.RowsAffected() == 0 {
+		return nil, ErrDeliveryNotFound
+	}
+
+	delivery, err := scanWebhookDelivery(tx.QueryRow(ctx, webhookDeliverySelect+`
+	WHERE d.delivery_id = $1`, deliveryId))
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query row: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%v failed to commit transaction: %w", op, err)
+	}
+
+	return &delivery, nil
+}
+
+// ClaimWebhookDeliveries returns due deliveries and postpones them by
+// the lease so that concurrent dispatchers skip deliveries in flight.
+func (s *Storage) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]PendingDelivery, error) {
+	const op = "postgres.ClaimWebhookDeliveries"
+	sql := `UPDATE webhook_deliveries d
+	SET next_attempt_at = NOW() + $2::interval
+	FROM outbox_events e, webhook_subscriptions s
+	WHERE d.delivery_id IN (
+			SELECT delivery_id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		AND e.event_id = d.event_id
+		AND s.subscription_id = d.subscription_id
+	RETURNING d.delivery_id, d.attempts, s.url, s.secret, e.event_id, e.event_type, e.createdAt, e.payload`
+	rows, err := s.db.Query(ctx, sql, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to query: %w", op, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (PendingDelivery, error) {
+		var d PendingDelivery
+		return d, row.Scan(
+			&d.DeliveryId,
+			&d.Attempts,
+			&d.URL,
+			&d.Secret,
+			&d.Payload.EventId,
+			&d.Payload.EventType,
+			&d.Payload.OccurredAt,
+			&d.Payload.Data,
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to collect rows: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+func (s *Storage) MarkWebhookDelivered(ctx context.Context, deliveryId int64, statusCode int) error {
+	sql := `UPDATE webhook_deliveries
+	SET
+		status = 'delivered',
+		attempts = attempts + 1,
+		last_status_code = $2,
+		last_error = NULL,
+		deliveredAt = NOW()
+	WHERE delivery_id = $1`
+	if _, err := s.db.Exec(ctx, sql, deliveryId, statusCode); err != nil {
+		return fmt.Errorf("postgres.MarkWebhookDelivered failed to execute update: %w", err)
+	}
+	return nil
+}
+
+// MarkWebhookFailed records a failed attempt. Without retryIn
+// the delivery is moved to the dead-letter state.
+func (s *Storage) MarkWebhookFailed(
+	ctx context.Context,
+	deliveryId int64,
+	statusCode *int,
+	lastError string,
+	retryIn *time.Duration,
+) error {
+	sql := `UPDATE webhook_deliveries
+	SET
+		status = CASE WHEN $4::interval IS NULL THEN 'dead' ELSE 'pending' END::delivery_status,
+		attempts = attempts + 1,
+		last_status_code = $2,
+		last_error = $3,
+		next_attempt_at = NOW() + COALESCE($4::interval, INTERVAL '0')
+	WHERE delivery_id = $1`
+	if _, err := s.db.Exec(ctx, sql, deliveryId, statusCode, lastError, retryIn); err != nil {
+		return fmt.Errorf("postgres.MarkWebhookFailed failed to execute update: %w", err)
+	}
+	return nil
+}
+
+func webhookEventType(t api.AssignmentEventEventType) api.WebhookEventType {
+	switch t {
+	case api.Created:
+		return api.PrCreated
+	case api.Merged:
+		return api.PrMerged
+	}
+	return api.PrReassigned
+}
+
+func scanWebhookSubscription(row pgx.Row) (api.WebhookSubscription, error) {
+	var sub api.WebhookSubscription
+	return sub, row.Scan(&sub.SubscriptionId, &sub.Url, &sub.EventTypes, &sub.CreatedAt)
+}
+
+func scanWebhookDelivery(row pgx.Row) (api.WebhookDelivery, error) {
+	var d api.WebhookDelivery
+	return d, row.Scan(
+		&d.DeliveryId,
+		&d.EventId,
+		&d.SubscriptionId,
+		&d.EventType,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.DeliveredAt,
+	)
+}
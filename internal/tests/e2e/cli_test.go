@@ -0,0 +1,63 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/cli"
+	"avito-trainee-task/pkg/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func prctl(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(ctx, append([]string{"-url", serverURL}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestPrctl(t *testing.T) {
+	code, out, errOut := prctl(t, "-actor", adminId, "team", "create", "testdata/prctl/teams.yaml")
+	require.Equal(t, 0, code, errOut)
+	require.Contains(t, out, "cli")
+
+	code, out, errOut = prctl(t, "-o", "json", "pr", "create", "-id", "cli-pr", "-name", "CLI PR", "-author", "cli1")
+	require.Equal(t, 0, code, errOut)
+	var pr client.PullRequest
+	require.NoError(t, json.Unmarshal([]byte(out), &pr))
+	require.ElementsMatch(t, []string{"cli2", "cli3"}, pr.AssignedReviewers)
+
+	code, out, errOut = prctl(t, "-o", "yaml", "pr", "list", "-team", "cli", "-all")
+	require.Equal(t, 0, code, errOut)
+	require.Contains(t, out, "pull_request_id: cli-pr")
+
+	code, _, errOut = prctl(t, "-actor", "cli1", "pr", "reassign", "-id", "cli-pr", "-old", "cli2")
+	require.Equal(t, 1, code)
+	require.Contains(t, errOut, "NO_CANDIDATE")
+
+	code, out, errOut = prctl(t, "user", "deactivate", "cli2")
+	require.Equal(t, 0, code, errOut)
+	require.Contains(t, out, "false")
+
+	code, _, errOut = prctl(t, "-actor", "cli3", "pr", "merge", "cli-pr")
+	require.Equal(t, 1, code)
+	require.Contains(t, errOut, "FORBIDDEN")
+
+	code, out, errOut = prctl(t, "-actor", "cli1", "pr", "merge", "cli-pr")
+	require.Equal(t, 0, code, errOut)
+	require.Contains(t, out, "MERGED")
+
+	code, _, errOut = prctl(t, "user", "get", "missing")
+	require.Equal(t, 1, code)
+	require.Contains(t, errOut, "NOT_FOUND")
+
+	code, _, _ = prctl(t, "pr", "merge")
+	require.Equal(t, 2, code)
+}
@@ -0,0 +1,72 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avito-trainee-task/pkg/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRetry(t *testing.T) {
+	var merges, creates atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pullRequest/merge":
+			var req struct {
+				PullRequestId string `json:"pull_request_id"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if json.Unmarshal(body, &req) != nil || req.PullRequestId != "retry-pr" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if merges.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"pr": {"pull_request_id": "retry-pr", "pull_request_name": "Retry",
+				"author_id": "u1", "status": "MERGED", "assigned_reviewers": []}}`))
+		case "/pullRequest/create":
+			creates.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.New(server.URL, client.WithRetry(client.Retry{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Merge is idempotent and is repeated after 503.
+	pr, err := c.MergePullRequest(ctx, "retry-pr")
+	require.NoError(t, err)
+	require.Equal(t, "retry-pr", pr.PullRequestId)
+	require.Equal(t, int32(2), merges.Load())
+
+	// Create could assign reviewers twice and is not repeated.
+	_, err = c.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "retry-pr",
+		PullRequestName: "Retry",
+		AuthorId:        "u1",
+	})
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	require.Equal(t, int32(1), creates.Load())
+}
@@ -0,0 +1,152 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/codehost"
+	"avito-trainee-task/internal/worker"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+type reviewerRequest struct {
+	method    string
+	reviewers []string
+}
+
+func TestReviewerSyncToGitHub(t *testing.T) {
+	const (
+		token = "github-test-token"
+		path  = "/repos/acme/sync/pulls/5/requested_reviewers"
+		prId  = "github:acme/sync#5"
+	)
+
+	var (
+		mu       sync.Mutex
+		requests []reviewerRequest
+		failures = 1
+	)
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var body struct {
+			Reviewers []string `json:"reviewers"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		slices.Sort(body.Reviewers)
+		requests = append(requests, reviewerRequest{method: r.Method, reviewers: body.Reviewers})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer fake.Close()
+
+	syncer := codehost.NewSyncer(storage, map[api.CodeHostProvider]codehost.Client{
+		api.Github: codehost.NewGitHubClient(fake.URL, token, 5*time.Second),
+	}, worker.Config{
+		BatchSize:   10,
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		Timeout:     5 * time.Second,
+	})
+	syncOnce := func() {
+		t.Helper()
+		time.Sleep(10 * time.Millisecond)
+		_, err := syncer.SyncOnce(context.Background())
+		require.NoError(t, err)
+	}
+	getPR := func() (*client.PullRequest, *client.ReviewerSync) {
+		t.Helper()
+		pr, sync, err := apiClient.GetPullRequest(context.Background(), prId)
+		require.NoError(t, err)
+		require.NotNil(t, sync)
+		return pr, sync
+	}
+
+	ctx := context.Background()
+	members := []client.TeamMember{}
+	for _, id := range []string{"sync1", "sync2", "sync3", "sync4"} {
+		members = append(members, client.TeamMember{UserId: id, Username: id, IsActive: true})
+	}
+	_, err := apiClient.AddTeam(ctx, client.Team{TeamName: "sync", Members: members})
+	require.NoError(t, err)
+	for _, m := range members {
+		_, err = apiClient.SetCodeHostAccount(client.WithActorContext(ctx, adminId), client.CodeHostAccount{
+			Provider: openapi.Github,
+			Login:    m.UserId + "-gh",
+			UserId:   m.UserId,
+		})
+		require.NoError(t, err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"action": "opened",
+		"number": 5,
+		"pull_request": map[string]any{
+			"title": "Sync reviewers",
+			"user":  map[string]string{"login": "sync1-gh"},
+		},
+		"repository": map[string]string{"full_name": "acme/sync"},
+	})
+	require.NoError(t, err)
+	resp := signedGitHubRequest(t, "pull_request", payload, gitHubWebhookSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	pr, state := getPR()
+	require.Equal(t, openapi.SyncPending, state.Status)
+	logins := []string{pr.AssignedReviewers[0] + "-gh", pr.AssignedReviewers[1] + "-gh"}
+	slices.Sort(logins)
+
+	syncOnce()
+	_, state = getPR()
+	require.Equal(t, openapi.SyncPending, state.Status)
+	require.Equal(t, 1, state.Attempts)
+	require.NotNil(t, state.LastError)
+
+	syncOnce()
+	_, state = getPR()
+	require.Equal(t, openapi.SyncSynced, state.Status)
+	require.Equal(t, []reviewerRequest{{method: http.MethodPost, reviewers: logins}}, requests)
+
+	old := pr.AssignedReviewers[0]
+	_, _, err = apiClient.ReassignPullRequest(ctx, client.ReassignPullRequestRequest{
+		PullRequestId: prId,
+		OldUserId:     old,
+	})
+	require.NoError(t, err)
+
+	syncOnce()
+	pr, state = getPR()
+	require.Equal(t, openapi.SyncSynced, state.Status)
+	logins = []string{pr.AssignedReviewers[0] + "-gh", pr.AssignedReviewers[1] + "-gh"}
+	slices.Sort(logins)
+	require.Len(t, requests, 3)
+	require.Equal(t, reviewerRequest{method: http.MethodDelete, reviewers: []string{old + "-gh"}}, requests[1])
+	require.Equal(t, reviewerRequest{method: http.MethodPost, reviewers: logins}, requests[2])
+}
@@ -0,0 +1,247 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/notify"
+	"avito-trainee-task/internal/worker"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// sentEmail is a message accepted by the SMTP sink.
+type sentEmail struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// startSMTPSink accepts SMTP sessions on a local port and parses
+// the received messages.
+func startSMTPSink(t *testing.T) (string, int, <-chan sentEmail) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan sentEmail, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(t, conn, received)
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, received
+}
+
+func serveSMTP(t *testing.T, conn net.Conn, received chan<- sentEmail) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = io.WriteString(conn, line+"\r\n")
+	}
+
+	reply("220 sink ready")
+	var to string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 sink")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			to = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			email, err := parseEmail(data.String())
+			if err != nil {
+				t.Errorf("failed to parse email: %v", err)
+				reply("554 bad message")
+				continue
+			}
+			email.To = to
+			received <- email
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func parseEmail(data string) (sentEmail, error) {
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		return sentEmail{}, err
+	}
+
+	var email sentEmail
+	email.Subject, err = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		return sentEmail{}, err
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return sentEmail{}, err
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return sentEmail{}, err
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			return sentEmail{}, err
+		}
+		if strings.HasPrefix(part.Header.Get("Content-Type"), "text/html") {
+			email.HTML = string(body)
+		} else {
+			email.Text = string(body)
+		}
+	}
+	return email, nil
+}
+
+func receiveEmail(t *testing.T, received <-chan sentEmail) sentEmail {
+	t.Helper()
+	select {
+	case email := <-received:
+		return email
+	case <-time.After(5 * time.Second):
+		t.Fatal("email was not received")
+	}
+	return sentEmail{}
+}
+
+func TestEmailNotifications(t *testing.T) {
+	host, port, received := startSMTPSink(t)
+
+	templates, err := notify.LoadEmailTemplates("")
+	require.NoError(t, err)
+	notifier := notify.NewEmailNotifier(storage, templates, notify.EmailConfig{
+		Host: host,
+		Port: port,
+		From: "reviewers@example.com",
+		Config: worker.Config{
+			BatchSize:   10,
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  time.Millisecond,
+			Timeout:     5 * time.Second,
+		},
+		ReminderInterval: time.Hour,
+	})
+
+	ctx := context.Background()
+	_, err = apiClient.AddTeam(ctx, client.Team{
+		TeamName: "email",
+		Members: []client.TeamMember{
+			{UserId: "email1", Username: "email1", IsActive: true},
+			{UserId: "email2", Username: "email2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	address := openapi_types.Email("email2@example.com")
+	_, err = apiClient.SetNotificationContact(ctx, client.NotificationContact{
+		UserId:      "email2",
+		Email:       &address,
+		EmailOptOut: &[]client.NotificationKind{"weekly"},
+	})
+	require.ErrorIs(t, err, client.ErrBadRequest)
+
+	_, err = apiClient.SetNotificationContact(ctx, client.NotificationContact{
+		UserId:      "email2",
+		Email:       &address,
+		EmailOptOut: &[]client.NotificationKind{openapi.KindMerged},
+	})
+	require.NoError(t, err)
+
+	// Notifications claimed together are sent as one email.
+	for i := range 2 {
+		_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+			PullRequestId:   "email-pr" + strconv.Itoa(i+1),
+			PullRequestName: "Email PR " + strconv.Itoa(i+1),
+			AuthorId:        "email1",
+		})
+		require.NoError(t, err)
+	}
+
+	sent, err := notifier.NotifyOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	email := receiveEmail(t, received)
+	require.Equal(t, "email2@example.com", email.To)
+	require.Equal(t, "2 review updates", email.Subject)
+	require.Contains(t, email.Text, `You were assigned to review "Email PR 1" (email-pr1)`)
+	require.Contains(t, email.Text, `You were assigned to review "Email PR 2" (email-pr2)`)
+	require.Contains(t, email.HTML, "<b>Email PR 2</b>")
+
+	// Opted out of merge emails.
+	_, err = apiClient.MergePullRequest(ctx, "email-pr1")
+	require.NoError(t, err)
+
+	sent, err = notifier.NotifyOnce(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent)
+
+	sent, err = notifier.RemindOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	email = receiveEmail(t, received)
+	require.Equal(t, "email2@example.com", email.To)
+	require.Equal(t, "1 pull request(s) waiting for your review", email.Subject)
+	require.Contains(t, email.Text, `"Email PR 2" (email-pr2) by email1`)
+	require.NotContains(t, email.Text, "email-pr1")
+
+	sent, err = notifier.RemindOnce(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent)
+}
@@ -0,0 +1,121 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"avito-trainee-task/internal/controller/http/integrations"
+	"avito-trainee-task/internal/webhook"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+func gitHubRequest(t *testing.T, event, fixture, secret string) *http.Response {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "github", fixture))
+	require.NoError(t, err)
+	return signedGitHubRequest(t, event, body, secret)
+}
+
+func signedGitHubRequest(t *testing.T, event string, body []byte, secret string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, serverURL+integrations.GitHubWebhookPath, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(integrations.GitHubEventHeader, event)
+	req.Header.Set(integrations.GitHubSignatureHeader, webhook.Sign(secret, body))
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestGitHubWebhook(t *testing.T) {
+	const prId = "github:acme/backend#42"
+
+	ctx := context.Background()
+	_, err := apiClient.AddTeam(ctx, client.Team{
+		TeamName: "github",
+		Members: []client.TeamMember{
+			{UserId: "gh1", Username: "gh1", IsActive: true},
+			{UserId: "gh2", Username: "gh2", IsActive: true},
+			{UserId: "gh3", Username: "gh3", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	resp := gitHubRequest(t, "pull_request", "pull_request_opened.json", "wrong-secret")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = gitHubRequest(t, "pull_request", "pull_request_opened.json", gitHubWebhookSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, integrations.StatusIgnored, decodeBody[integrations.Result](t, resp).Status)
+
+	// Mapping a login decides who authors pull requests, only leads change it.
+	_, err = apiClient.SetCodeHostAccount(ctx, client.CodeHostAccount{
+		Provider: openapi.Github,
+		Login:    "octocat",
+		UserId:   "gh1",
+	})
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	for login, userId := range map[string]string{"octocat": "gh1", "hubot": "gh2"} {
+		_, err = apiClient.SetCodeHostAccount(client.WithActorContext(ctx, adminId), client.CodeHostAccount{
+			Provider: openapi.Github,
+			Login:    login,
+			UserId:   userId,
+		})
+		require.NoError(t, err)
+	}
+
+	// Redelivered events must not fail once the pull request exists.
+	for range 2 {
+		resp = gitHubRequest(t, "pull_request", "pull_request_opened.json", gitHubWebhookSecret)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		result := decodeBody[integrations.Result](t, resp)
+		require.Equal(t, integrations.StatusCreated, result.Status)
+		require.Equal(t, prId, result.PullRequestId)
+	}
+
+	pr, _, err := apiClient.GetPullRequest(ctx, prId)
+	require.NoError(t, err)
+	require.Equal(t, "gh1", pr.AuthorId)
+	require.Equal(t, "Add rate limiting to public API", pr.PullRequestName)
+	require.Len(t, pr.AssignedReviewers, 2)
+	require.Equal(t, openapi.PullRequestStatusOPEN, pr.Status)
+
+	resp = gitHubRequest(t, "pull_request", "pull_request_closed_unmerged.json", gitHubWebhookSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, integrations.StatusIgnored, decodeBody[integrations.Result](t, resp).Status)
+
+	resp = gitHubRequest(t, "pull_request", "pull_request_closed_merged.json", gitHubWebhookSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	result := decodeBody[integrations.Result](t, resp)
+	require.Equal(t, integrations.StatusMerged, result.Status)
+	require.Equal(t, prId, result.PullRequestId)
+
+	history, err := apiClient.GetPullRequestHistory(ctx, client.PullRequestHistoryParams{PullRequestId: prId})
+	require.NoError(t, err)
+	events := history.Events
+	require.Len(t, events, 2)
+	require.Equal(t, openapi.Merged, events[0].EventType)
+	require.Equal(t, "gh2", *events[0].ActorId)
+	require.Equal(t, openapi.Created, events[1].EventType)
+	require.Equal(t, "gh1", *events[1].ActorId)
+
+	details, err := apiClient.GetPullRequestDetails(ctx, prId)
+	require.NoError(t, err)
+	require.Equal(t, openapi.PullRequestStatusMERGED, details.Pr.Status)
+	require.Equal(t, *history, details.History)
+
+	resp = gitHubRequest(t, "ping", "pull_request_opened.json", gitHubWebhookSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, integrations.StatusPong, decodeBody[integrations.Result](t, resp).Status)
+}
@@ -0,0 +1,99 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"avito-trainee-task/internal/controller/http/integrations"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+func gitLabRequest(t *testing.T, fixture, token string) *http.Response {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "gitlab", fixture))
+	require.NoError(t, err)
+	defer f.Close()
+
+	req, err := http.NewRequest(http.MethodPost, serverURL+integrations.GitLabWebhookPath, f)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(integrations.GitLabEventHeader, "Merge Request Hook")
+	req.Header.Set(integrations.GitLabTokenHeader, token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestGitLabWebhook(t *testing.T) {
+	const prId = "gitlab:payments/billing!7"
+
+	ctx := context.Background()
+	_, err := apiClient.AddTeam(ctx, client.Team{
+		TeamName: "gitlab",
+		Members: []client.TeamMember{
+			{UserId: "gl1", Username: "gl1", IsActive: true},
+			{UserId: "gl2", Username: "gl2", IsActive: true},
+			{UserId: "gl3", Username: "gl3", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	for login, userId := range map[string]string{"jsmith": "gl1", "mlee": "gl2"} {
+		_, err = apiClient.SetCodeHostAccount(client.WithActorContext(ctx, adminId), client.CodeHostAccount{
+			Provider: openapi.Gitlab,
+			Login:    login,
+			UserId:   userId,
+		})
+		require.NoError(t, err)
+	}
+
+	resp := gitLabRequest(t, "merge_request_open.json", "wrong-token")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	// Redelivered and reopened hooks must not fail with PR_EXISTS.
+	for _, fixture := range []string{
+		"merge_request_open.json",
+		"merge_request_open.json",
+		"merge_request_reopen.json",
+	} {
+		resp = gitLabRequest(t, fixture, gitLabWebhookToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode, fixture)
+		result := decodeBody[integrations.Result](t, resp)
+		require.Equal(t, integrations.StatusCreated, result.Status, fixture)
+		require.Equal(t, prId, result.PullRequestId, fixture)
+	}
+
+	resp = gitLabRequest(t, "merge_request_close.json", gitLabWebhookToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, integrations.StatusIgnored, decodeBody[integrations.Result](t, resp).Status)
+
+	for range 2 {
+		resp = gitLabRequest(t, "merge_request_merge.json", gitLabWebhookToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		result := decodeBody[integrations.Result](t, resp)
+		require.Equal(t, integrations.StatusMerged, result.Status)
+		require.Equal(t, prId, result.PullRequestId)
+	}
+
+	pr, _, err := apiClient.GetPullRequest(ctx, prId)
+	require.NoError(t, err)
+	require.Equal(t, "gl1", pr.AuthorId)
+	require.Equal(t, "Fix invoice rounding for multi-currency totals", pr.PullRequestName)
+	require.Equal(t, openapi.PullRequestStatusMERGED, pr.Status)
+
+	history, err := apiClient.GetPullRequestHistory(ctx, client.PullRequestHistoryParams{PullRequestId: prId})
+	require.NoError(t, err)
+	events := history.Events
+	require.Len(t, events, 2)
+	require.Equal(t, openapi.Merged, events[0].EventType)
+	require.Equal(t, "gl2", *events[0].ActorId)
+	require.Equal(t, openapi.Created, events[1].EventType)
+}
@@ -0,0 +1,113 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+	"avito-trainee-task/internal/controller/rpc"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func requireCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err), err.Error())
+}
+
+func TestGRPCPullRequestLifecycle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := grpcClient.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{
+		TeamName: "grpc",
+		Members:  []*pb.TeamMember{{UserId: "grpc1", Username: "grpc1", IsActive: true, Role: "lead"}},
+	}})
+	requireCode(t, codes.PermissionDenied, err)
+
+	adminCtx := metadata.AppendToOutgoingContext(ctx, rpc.ActorMetadataKey, adminId)
+	team, err := grpcClient.AddTeam(adminCtx, &pb.AddTeamRequest{Team: &pb.Team{
+		TeamName: "grpc",
+		Members: []*pb.TeamMember{
+			{UserId: "grpc1", Username: "grpc1", IsActive: true, Role: "lead"},
+			{UserId: "grpc2", Username: "grpc2", IsActive: true},
+			{UserId: "grpc3", Username: "grpc3", IsActive: true},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, team.GetTeam().GetMembers(), 3)
+
+	_, err = grpcClient.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{TeamName: "grpc"}})
+	requireCode(t, codes.AlreadyExists, err)
+
+	after, err := storage.LastEventId(ctx)
+	require.NoError(t, err)
+	events, err := grpcClient.StreamEvents(ctx, &pb.StreamEventsRequest{TeamName: "grpc", AfterEventId: &after})
+	require.NoError(t, err)
+
+	created, err := grpcClient.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+		PullRequestId:   "grpc-pr",
+		PullRequestName: "gRPC PR",
+		AuthorId:        "grpc1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "OPEN", created.GetPr().GetStatus())
+	require.ElementsMatch(t, []string{"grpc2", "grpc3"}, created.GetPr().GetAssignedReviewers())
+	require.NotNil(t, created.GetPr().GetCreatedAt())
+
+	_, err = grpcClient.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+		PullRequestId:   "grpc-pr2",
+		PullRequestName: "gRPC PR",
+		AuthorId:        "missing",
+	})
+	requireCode(t, codes.NotFound, err)
+
+	event, err := events.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "created", event.GetEventType())
+	require.Equal(t, "grpc-pr", event.GetPullRequestId())
+
+	// Only the team lead reassigns other reviewers.
+	_, err = grpcClient.ReassignPullRequest(
+		metadata.AppendToOutgoingContext(ctx, rpc.ActorMetadataKey, "grpc3"),
+		&pb.ReassignPullRequestRequest{PullRequestId: "grpc-pr", OldUserId: "grpc2"},
+	)
+	requireCode(t, codes.PermissionDenied, err)
+
+	_, err = grpcClient.ReassignPullRequest(
+		metadata.AppendToOutgoingContext(ctx, rpc.ActorMetadataKey, "grpc1"),
+		&pb.ReassignPullRequestRequest{PullRequestId: "grpc-pr", OldUserId: "grpc2"},
+	)
+	requireCode(t, codes.FailedPrecondition, err)
+
+	merged, err := grpcClient.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "grpc-pr"})
+	require.NoError(t, err)
+	require.Equal(t, "MERGED", merged.GetPr().GetStatus())
+	require.NotNil(t, merged.GetPr().GetMergedAt())
+
+	event, err = events.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "merged", event.GetEventType())
+
+	_, err = grpcClient.GetPullRequest(ctx, &pb.GetPullRequestRequest{PullRequestId: "missing"})
+	requireCode(t, codes.NotFound, err)
+
+	review, err := grpcClient.GetReview(ctx, &pb.GetReviewRequest{UserId: "grpc2", Status: "ALL"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, review.GetTotal())
+	require.Equal(t, "grpc-pr", review.GetPullRequests()[0].GetPullRequestId())
+
+	_, err = grpcClient.ListPullRequests(ctx, &pb.ListPullRequestsRequest{Page: &pb.Page{Cursor: "???"}})
+	requireCode(t, codes.InvalidArgument, err)
+
+	stats, err := grpcClient.GetStats(ctx, &pb.GetStatsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, stats.GetStats())
+	require.NotEmpty(t, stats.GetTeams())
+	require.NotEmpty(t, stats.GetPullRequests())
+}
@@ -0,0 +1,56 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-trainee-task/internal/api"
+	v1 "avito-trainee-task/internal/controller/http/v1"
+	"avito-trainee-task/internal/migration"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	resp, err := http.Get(serverURL + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(serverURL + "/readyz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var ready api.Readiness
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ready))
+	require.Equal(t, api.HealthOk, ready.Status)
+	require.Len(t, ready.Checks, 3)
+
+	version, err := migration.ExpectedVersion()
+	require.NoError(t, err)
+	require.NotZero(t, version)
+	require.Equal(t, fmt.Sprintf("version %d", version), *ready.Checks["migrations"].Message)
+
+	// A draining server fails readiness while liveness still passes.
+	h := v1.NewHandler(storage)
+	e := echo.New()
+	h.RegisterRoutes(e)
+	h.Drain()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&ready))
+	require.Equal(t, api.HealthFail, ready.Status)
+	require.Equal(t, api.HealthFail, ready.Checks["draining"].Status)
+	require.Equal(t, api.HealthOk, ready.Checks["database"].Status)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,79 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportDryRun(t *testing.T) {
+	ctx := context.Background()
+	chart := `team_name,user_id,username,is_active,role
+import-team,imp1,Alice,true,lead
+import-team,imp2,Bob,false,member
+`
+	dryRun := true
+	report, err := apiClient.Import(ctx, client.ImportCSV, strings.NewReader(chart), client.ImportParams{DryRun: &dryRun})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, []string{"imp1", "imp2"}, report.Created)
+
+	_, err = apiClient.GetTeam(ctx, "import-team")
+	require.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestImportApplyRequiresLead(t *testing.T) {
+	ctx := context.Background()
+	chart := `team_name,user_id,username,is_active,role
+import-team,imp1,Alice,true,lead
+`
+	_, err := apiClient.Import(ctx, client.ImportCSV, strings.NewReader(chart), client.ImportParams{})
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	actorCtx := client.WithActorContext(ctx, "imp1")
+	_, err = apiClient.Import(actorCtx, client.ImportCSV, strings.NewReader(chart), client.ImportParams{})
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	_, err = apiClient.GetTeam(ctx, "import-team")
+	require.ErrorIs(t, err, client.ErrNotFound)
+
+	// Any lead creates a new team, changes need the lead of every team touched.
+	adminCtx := client.WithActorContext(ctx, adminId)
+	report, err := apiClient.Import(adminCtx, client.ImportCSV, strings.NewReader(chart), client.ImportParams{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"imp1"}, report.Created)
+
+	moving := `team_name,user_id,username,is_active,role
+import-other,imp1,Alice,true,member
+`
+	_, err = apiClient.Import(adminCtx, client.ImportCSV, strings.NewReader(moving), client.ImportParams{})
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	report, err = apiClient.Import(actorCtx, client.ImportCSV, strings.NewReader(moving), client.ImportParams{})
+	require.NoError(t, err)
+	require.Equal(t, []client.ImportMove{{UserId: "imp1", FromTeam: "import-team", ToTeam: "import-other"}}, report.Moved)
+}
+
+func TestImportInvalidChart(t *testing.T) {
+	chart := `teams:
+  - team_name: import-team
+    members:
+      - user_id: imp1
+        username: Alice
+      - user_id: imp1
+        username: Alice
+`
+	dryRun := true
+	_, err := apiClient.Import(context.Background(), client.ImportYAML, strings.NewReader(chart), client.ImportParams{DryRun: &dryRun})
+	require.ErrorIs(t, err, client.ErrBadRequest)
+
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, string(openapi.BADREQUEST), apiErr.Code)
+}
@@ -1,110 +1,201 @@
 package e2e
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io"
 	"log"
-	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"avito-trainee-task/internal/api"
+	pb "avito-trainee-task/internal/api/grpc/reviewerv1"
+	"avito-trainee-task/internal/controller/http/integrations"
+	"avito-trainee-task/internal/controller/http/scim"
+	"avito-trainee-task/internal/controller/http/stream"
+	"avito-trainee-task/internal/controller/rpc"
+	"avito-trainee-task/internal/events"
+	"avito-trainee-task/internal/metrics"
+	"avito-trainee-task/internal/storage/postgres"
 	"avito-trainee-task/internal/tests"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
 
 	"github.com/stretchr/testify/require"
 )
 
 const (
 	schemeMigrationsPath = "../../../migrations/"
+
+	scimToken       = "scim-test-token"
+	scimDefaultTeam = "unassigned"
+
+	gitHubWebhookSecret = "github-test-secret"
+	gitLabWebhookToken  = "gitlab-test-token"
+
+	streamKeepAlive = time.Second
+
+	// adminId leads a team of its own and is the actor for adding leads.
+	adminId = "e2e-admin"
 )
 
-var serverURL string
+var (
+	serverURL  string
+	storage    *postgres.Storage
+	grpcClient pb.ReviewerServiceClient
+	apiClient  *client.Client
+)
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 
-	s, err := tests.CreatePostgresStorage(ctx, schemeMigrationsPath)
+	var err error
+	storage, err = tests.CreatePostgresStorage(ctx, schemeMigrationsPath)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	lead := api.Lead
+	_, err = storage.AddTeam(ctx, api.Team{
+		TeamName: "e2e-admins",
+		Members: []api.TeamMember{
+			{UserId: adminId, Username: adminId, IsActive: true, Role: &lead},
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	brokerCtx, stopBroker := context.WithCancel(ctx)
+	broker := events.NewBroker(storage, events.Config{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second,
+	})
+	go broker.Run(brokerCtx)
+
+	metrics.Registry.MustRegister(metrics.NewPullRequestCollector(storage))
+
 	var cleanup func()
-	serverURL, cleanup, err = tests.StartServer(s)
+	serverURL, cleanup, err = tests.StartServer(
+		storage,
+		scim.NewHandler(storage, scimToken, scimDefaultTeam).RegisterRoutes,
+		integrations.NewGitHubHandler(storage, gitHubWebhookSecret).RegisterRoutes,
+		integrations.NewGitLabHandler(storage, gitLabWebhookToken).RegisterRoutes,
+		stream.NewHandler(storage, broker, streamKeepAlive).RegisterRoutes,
+		metrics.RegisterRoutes,
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer cleanup()
+	defer stopBroker()
+
+	conn, stopGRPC, err := tests.StartGRPCServer(rpc.NewServer(storage, broker, streamKeepAlive).Register)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stopGRPC()
+	grpcClient = pb.NewReviewerServiceClient(conn)
+
+	apiClient, err = client.New(serverURL)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	code := m.Run()
 	os.Exit(code)
 }
 
+func TestAddTeamAuthorization(t *testing.T) {
+	ctx := context.Background()
+	lead := openapi.Lead
+
+	_, err := apiClient.AddTeam(ctx, client.Team{
+		TeamName: "auth-a",
+		Members: []client.TeamMember{
+			{UserId: "auth-lead", Username: "auth-lead", IsActive: true, Role: &lead},
+		},
+	})
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	_, err = apiClient.AddTeam(client.WithActorContext(ctx, adminId), client.Team{
+		TeamName: "auth-a",
+		Members: []client.TeamMember{
+			{UserId: "auth-lead", Username: "auth-lead", IsActive: true, Role: &lead},
+			{UserId: "auth1", Username: "auth1", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	// Only the lead of auth-a moves its members, a lead of another team can't.
+	moved := client.Team{
+		TeamName: "auth-b",
+		Members: []client.TeamMember{
+			{UserId: "auth1", Username: "auth1", IsActive: true},
+		},
+	}
+	_, err = apiClient.AddTeam(ctx, moved)
+	require.ErrorIs(t, err, client.ErrForbidden)
+	_, err = apiClient.AddTeam(client.WithActorContext(ctx, adminId), moved)
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	// A member without a role keeps the stored one.
+	moved.Members = append(moved.Members, client.TeamMember{UserId: "auth-lead", Username: "auth-lead", IsActive: true})
+	team, err := apiClient.AddTeam(client.WithActorContext(ctx, "auth-lead"), moved)
+	require.NoError(t, err)
+	for _, m := range team.Members {
+		if m.UserId == "auth-lead" {
+			require.Equal(t, lead, *m.Role)
+		}
+	}
+}
+
 func TestTeamPRReassign(t *testing.T) {
-	team := api.Team{
+	ctx := context.Background()
+
+	_, err := apiClient.AddTeam(ctx, client.Team{
 		TeamName: "t1",
-		Members: []api.TeamMember{
+		Members: []client.TeamMember{
 			{UserId: "u1", Username: "u1", IsActive: true},
 			{UserId: "u2", Username: "u2", IsActive: true},
 			{UserId: "u3", Username: "u3", IsActive: true},
 			{UserId: "u4", Username: "u4", IsActive: true},
 		},
-	}
-
-	teamJSON, _ := json.Marshal(team)
-	resp, err := http.Post(serverURL+"/team/add", "application/json", bytes.NewReader(teamJSON))
+	})
 	require.NoError(t, err)
-	require.Equal(t, 201, resp.StatusCode)
-	defer resp.Body.Close()
 
-	prReq := api.PostPullRequestCreateJSONBody{
+	_, err = apiClient.AddTeam(ctx, client.Team{TeamName: "t1", Members: []client.TeamMember{}})
+	require.ErrorIs(t, err, client.ErrTeamExists)
+
+	pr, err := apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
 		PullRequestId:   "01",
 		PullRequestName: "test",
 		AuthorId:        "u1",
-	}
-
-	prJSON, _ := json.Marshal(prReq)
-	resp, err = http.Post(serverURL+"/pullRequest/create", "application/json", bytes.NewReader(prJSON))
+	})
 	require.NoError(t, err)
-	require.Equal(t, 201, resp.StatusCode)
-	defer resp.Body.Close()
-
-	var prResp map[string]api.PullRequest
-	body, _ := io.ReadAll(resp.Body)
-	err = json.Unmarshal(body, &prResp)
-	require.NoError(t, err)
-
-	pr := prResp["pr"]
 	require.Len(t, pr.AssignedReviewers, 2)
 
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "01",
+		PullRequestName: "test",
+		AuthorId:        "u1",
+	})
+	require.ErrorIs(t, err, client.ErrPullRequestExists)
+
 	oldReviewer := pr.AssignedReviewers[0]
-	reassignReq := api.PostPullRequestReassignJSONBody{
+	updatedPR, replacedBy, err := apiClient.ReassignPullRequest(ctx, client.ReassignPullRequestRequest{
 		OldUserId:     oldReviewer,
 		PullRequestId: "01",
-	}
-
-	reassignJSON, _ := json.Marshal(reassignReq)
-	resp, err = http.Post(serverURL+"/pullRequest/reassign", "application/json", bytes.NewReader(reassignJSON))
-	require.NoError(t, err)
-	require.Equal(t, 200, resp.StatusCode)
-	defer resp.Body.Close()
-
-	type ReassignResponse struct {
-		PR         api.PullRequest `json:"pr"`
-		ReplacedBy string          `json:"replaced_by"`
-	}
-
-	var reassignResp ReassignResponse
-	body, _ = io.ReadAll(resp.Body)
-	err = json.Unmarshal(body, &reassignResp)
+	})
 	require.NoError(t, err)
 
-	updatedPR := reassignResp.PR
-	replacedBy := reassignResp.ReplacedBy
-
 	reviewers := updatedPR.AssignedReviewers
 	require.NotContains(t, reviewers, oldReviewer)
 	require.Contains(t, reviewers, replacedBy)
 	require.Len(t, reviewers, 2)
+
+	_, _, err = apiClient.ReassignPullRequest(ctx, client.ReassignPullRequestRequest{
+		OldUserId:     oldReviewer,
+		PullRequestId: "01",
+	})
+	require.ErrorIs(t, err, client.ErrNotAssigned)
 }
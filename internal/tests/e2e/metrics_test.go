@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"avito-trainee-task/pkg/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := apiClient.AddTeam(ctx, client.Team{
+		TeamName: "metrics",
+		Members: []client.TeamMember{
+			{UserId: "metrics-u1", Username: "alice", IsActive: true},
+			{UserId: "metrics-u2", Username: "bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	pr, err := apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "metrics-pr",
+		PullRequestName: "Metrics",
+		AuthorId:        "metrics-u1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"metrics-u2"}, pr.AssignedReviewers)
+
+	_, _, err = apiClient.ReassignPullRequest(ctx, client.ReassignPullRequestRequest{
+		PullRequestId: "metrics-pr",
+		OldUserId:     "metrics-u2",
+	})
+	require.ErrorIs(t, err, client.ErrNoCandidate)
+
+	resp, err := http.Get(serverURL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `pr_reviewer_open_pull_requests{team="metrics"} 1`)
+	require.Contains(t, string(body), `pr_reviewer_pull_request_reviewers_assigned_total{reviewers="1"}`)
+	require.Contains(t, string(body), `pr_reviewer_reassignments_total{result="no_candidate"}`)
+	require.Contains(t, string(body), `pr_reviewer_http_requests_total{code="409",method="POST",route="/pullRequest/reassign"}`)
+	require.Contains(t, string(body), `pr_reviewer_http_request_duration_seconds_bucket{method="POST",route="/pullRequest/create",le="0.3"}`)
+}
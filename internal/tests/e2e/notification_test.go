@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/notify"
+	"avito-trainee-task/internal/worker"
+	"avito-trainee-task/pkg/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatNotifications(t *testing.T) {
+	received := make(chan notify.ChatMessage, 10)
+	chat := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg notify.ChatMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- msg
+	}))
+	defer chat.Close()
+
+	templates, err := notify.ParseTemplates(map[api.NotificationKind]string{
+		api.KindAssigned: "{{.Mention}} review {{.PullRequestName}}",
+	})
+	require.NoError(t, err)
+	notifier := notify.NewChatNotifier(storage, chat.URL, templates, worker.Config{
+		BatchSize:   10,
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		Timeout:     5 * time.Second,
+	})
+
+	ctx := context.Background()
+	_, err = apiClient.AddTeam(ctx, client.Team{
+		TeamName: "chat",
+		Members: []client.TeamMember{
+			{UserId: "chat1", Username: "chat1", IsActive: true},
+			{UserId: "chat2", Username: "chat2", IsActive: true},
+			{UserId: "chat3", Username: "chat3", IsActive: true},
+			{UserId: "chat4", Username: "chat4", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = apiClient.SetNotificationContact(ctx, client.NotificationContact{UserId: "chat2"})
+	require.ErrorIs(t, err, client.ErrBadRequest)
+	for _, id := range []string{"chat2", "chat3", "chat4"} {
+		channel, handle := "@"+id, id+"-handle"
+		_, err = apiClient.SetNotificationContact(ctx, client.NotificationContact{
+			UserId:      id,
+			ChatChannel: &channel,
+			ChatHandle:  &handle,
+		})
+		require.NoError(t, err)
+	}
+
+	pr, err := apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "chat-pr1",
+		PullRequestName: "Chat PR",
+		AuthorId:        "chat1",
+	})
+	require.NoError(t, err)
+
+	sent, err := notifier.NotifyOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+
+	var messages []notify.ChatMessage
+	for range 2 {
+		select {
+		case msg := <-received:
+			messages = append(messages, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatal("chat message was not received")
+		}
+	}
+	slices.SortFunc(messages, func(a, b notify.ChatMessage) int {
+		return strings.Compare(a.Channel, b.Channel)
+	})
+	reviewers := slices.Sorted(slices.Values(pr.AssignedReviewers))
+	require.Equal(t, []notify.ChatMessage{
+		{Channel: "@" + reviewers[0], Text: "@" + reviewers[0] + "-handle review Chat PR"},
+		{Channel: "@" + reviewers[1], Text: "@" + reviewers[1] + "-handle review Chat PR"},
+	}, messages)
+
+	_, err = apiClient.MergePullRequest(ctx, "chat-pr1")
+	require.NoError(t, err)
+
+	sent, err = notifier.NotifyOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	for range 2 {
+		select {
+		case msg := <-received:
+			require.Contains(t, msg.Text, "was merged")
+		case <-time.After(5 * time.Second):
+			t.Fatal("chat message was not received")
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"avito-trainee-task/internal/controller/http/scim"
+
+	"github.com/stretchr/testify/require"
+)
+
+const scimUserId = "00u1abcd2EFGhijk3l4"
+
+func scimRequest(t *testing.T, method, path, fixture string) *http.Response {
+	var body io.Reader
+	if fixture != "" {
+		f, err := os.Open(filepath.Join("testdata", "scim", fixture))
+		require.NoError(t, err)
+		defer f.Close()
+		body = f
+	}
+
+	req, err := http.NewRequest(method, serverURL+scim.BasePath+path, body)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+scimToken)
+	req.Header.Set("Content-Type", scim.ContentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeBody[T any](t *testing.T, resp *http.Response) T {
+	defer resp.Body.Close()
+
+	var v T
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&v))
+	return v
+}
+
+func TestSCIMProvisioning(t *testing.T) {
+	resp := scimRequest(t, http.MethodPost, "/Users", "okta_create_user.json")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	user := decodeBody[scim.User](t, resp)
+	require.Equal(t, scimUserId, user.Id)
+	require.Equal(t, "jane.doe@example.com", user.UserName)
+	require.True(t, *user.Active)
+	require.Equal(t, scimDefaultTeam, user.Enterprise.Department)
+
+	resp = scimRequest(t, http.MethodPost, "/Users", "okta_create_user.json")
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	scimErr := decodeBody[scim.Error](t, resp)
+	require.Equal(t, []string{scim.ErrorSchema}, scimErr.Schemas)
+	require.Equal(t, "uniqueness", scimErr.ScimType)
+
+	filter := url.QueryEscape(`userName eq "jane.doe@example.com"`)
+	resp = scimRequest(t, http.MethodGet, "/Users?filter="+filter, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	list := decodeBody[scim.ListResponse](t, resp)
+	require.Equal(t, 1, list.TotalResults)
+
+	resp = scimRequest(t, http.MethodPost, "/Groups", "okta_create_group.json")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	group := decodeBody[scim.Group](t, resp)
+	require.Equal(t, "scim-platform", group.Id)
+	require.Len(t, group.Members, 1)
+	require.Equal(t, scimUserId, group.Members[0].Value)
+
+	_, err := apiClient.GetTeam(context.Background(), "scim-platform")
+	require.NoError(t, err)
+
+	resp = scimRequest(t, http.MethodPatch, "/Users/"+scimUserId, "azure_patch_user_deactivate.json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	user = decodeBody[scim.User](t, resp)
+	require.False(t, *user.Active)
+
+	resp = scimRequest(t, http.MethodPatch, "/Users/"+scimUserId, "azure_patch_user_name.json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	user = decodeBody[scim.User](t, resp)
+	require.Equal(t, "janet.doe@example.com", user.UserName)
+	require.False(t, *user.Active)
+
+	resp = scimRequest(t, http.MethodPatch, "/Groups/scim-platform", "okta_patch_group_remove_member.json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	group = decodeBody[scim.Group](t, resp)
+	require.Empty(t, group.Members)
+
+	resp = scimRequest(t, http.MethodGet, "/Users/"+scimUserId, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	user = decodeBody[scim.User](t, resp)
+	require.Equal(t, scimDefaultTeam, user.Enterprise.Department)
+}
+
+func TestSCIMErrors(t *testing.T) {
+	resp := scimRequest(t, http.MethodGet, "/Users/NONEXISTENT", "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), scim.ContentType))
+	scimErr := decodeBody[scim.Error](t, resp)
+	require.Equal(t, "404", scimErr.Status)
+
+	resp = scimRequest(t, http.MethodGet, "/Users?filter="+url.QueryEscape(`name.familyName co "Doe"`), "")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	scimErr = decodeBody[scim.Error](t, resp)
+	require.Equal(t, "invalidFilter", scimErr.ScimType)
+
+	resp = scimRequest(t, http.MethodPost, "/Groups", "okta_create_group_empty.json")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	scimErr = decodeBody[scim.Error](t, resp)
+	require.Equal(t, "invalidValue", scimErr.ScimType)
+
+	resp = scimRequest(t, http.MethodGet, "/Groups/scim-empty", "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err := http.Get(serverURL + scim.BasePath + "/Users")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
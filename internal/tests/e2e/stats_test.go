@@ -0,0 +1,83 @@
+package e2e
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"testing"
+
+	"avito-trainee-task/pkg/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCSV(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := apiClient.AddTeam(ctx, client.Team{
+		TeamName: "csv",
+		Members: []client.TeamMember{
+			{UserId: "csv-u1", Username: "alice", IsActive: true},
+			{UserId: "csv-u2", Username: "bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "csv-pr",
+		PullRequestName: "CSV",
+		AuthorId:        "csv-u1",
+	})
+	require.NoError(t, err)
+
+	get := func(path string) (*http.Response, [][]string) {
+		req, err := http.NewRequest(http.MethodGet, serverURL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/csv")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		return resp, records
+	}
+
+	resp, records := get("/users/stats?table=teams")
+	require.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	require.Equal(t, []string{"team_name", "pull_request_count", "open_count", "merged_count", "reassignment_count"}, records[0])
+	require.Contains(t, records, []string{"csv", "1", "1", "0", "0"})
+
+	_, records = get("/users/stats")
+	require.Equal(t, "user_id", records[0][0])
+	require.Contains(t, records, []string{"csv-u2", "1", "1", "0"})
+
+	_, records = get("/stats/fairness?team_name=csv&table=members")
+	require.Equal(t, [][]string{
+		{"team_name", "user_id", "assignment_count"},
+		{"csv", "csv-u1", "0"},
+		{"csv", "csv-u2", "1"},
+	}, records)
+
+	_, records = get("/stats/timeToMerge?team_name=csv")
+	require.Equal(t, [][]string{{"team_name", "week", "merged_count", "median_seconds", "p90_seconds"}}, records)
+
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "=HYPERLINK(\"https://example.com\")",
+		PullRequestName: "CSV injection",
+		AuthorId:        "csv-u2",
+	})
+	require.NoError(t, err)
+
+	_, records = get("/users/stats?table=pull_requests")
+	require.Contains(t, records, []string{"'=HYPERLINK(\"https://example.com\")", "csv-u2", "OPEN", "1", "0"})
+
+	resp, _ = get("/users/stats?table=unknown")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Without text/csv in Accept the response stays JSON.
+	stats, err := apiClient.GetStats(ctx, client.StatsParams{})
+	require.NoError(t, err)
+	require.NotEmpty(t, stats.Teams)
+}
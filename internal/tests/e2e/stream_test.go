@@ -0,0 +1,155 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/controller/http/stream"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	Id    string
+	Event string
+	Data  string
+}
+
+// openStream connects to the event stream and returns received events.
+// The stream is closed by cancel.
+func openStream(t *testing.T, query, lastEventId string) (*http.Response, <-chan sseEvent, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+stream.EventsStreamPath+"?"+query, nil)
+	require.NoError(t, err)
+	if lastEventId != "" {
+		req.Header.Set(stream.LastEventIdHeader, lastEventId)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	received := make(chan sseEvent, 10)
+	if resp.StatusCode == http.StatusOK {
+		go readEvents(resp.Body, received)
+	}
+	return resp, received, func() {
+		cancel()
+		resp.Body.Close()
+	}
+}
+
+func readEvents(body io.Reader, received chan<- sseEvent) {
+	defer close(received)
+
+	var event sseEvent
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		field, value, _ := strings.Cut(scanner.Text(), ": ")
+		switch field {
+		case "id":
+			event.Id = value
+		case "event":
+			event.Event = value
+		case "data":
+			event.Data = value
+		case "":
+			if event.Data != "" {
+				received <- event
+			}
+			event = sseEvent{}
+		}
+	}
+}
+
+func receiveEvent(t *testing.T, received <-chan sseEvent) client.AssignmentEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-received:
+		require.True(t, ok, "stream closed")
+		var e client.AssignmentEvent
+		require.NoError(t, json.Unmarshal([]byte(event.Data), &e))
+		require.Equal(t, strconv.FormatInt(e.EventId, 10), event.Id)
+		require.Equal(t, string(e.EventType), event.Event)
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not received")
+	}
+	return client.AssignmentEvent{}
+}
+
+func TestEventStream(t *testing.T) {
+	ctx := context.Background()
+	_, err := apiClient.AddTeam(ctx, client.Team{
+		TeamName: "stream",
+		Members: []client.TeamMember{
+			{UserId: "stream1", Username: "stream1", IsActive: true},
+			{UserId: "stream2", Username: "stream2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, _, closeStream := openStream(t, "user_id=missing", "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	closeStream()
+
+	resp, _, closeStream = openStream(t, "team_name=stream", "latest")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	closeStream()
+
+	resp, received, closeStream := openStream(t, "team_name=stream", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Events of other teams are filtered out.
+	_, err = apiClient.AddTeam(ctx, client.Team{
+		TeamName: "stream-other",
+		Members: []client.TeamMember{
+			{UserId: "stream-other1", Username: "stream-other1", IsActive: true},
+			{UserId: "stream-other2", Username: "stream-other2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "stream-other-pr",
+		PullRequestName: "Other PR",
+		AuthorId:        "stream-other1",
+	})
+	require.NoError(t, err)
+
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "stream-pr",
+		PullRequestName: "Stream PR",
+		AuthorId:        "stream1",
+	})
+	require.NoError(t, err)
+
+	created := receiveEvent(t, received)
+	require.Equal(t, openapi.Created, created.EventType)
+	require.Equal(t, "stream-pr", created.PullRequestId)
+	require.Equal(t, []string{"stream2"}, created.Reviewers)
+	closeStream()
+
+	// Events committed while disconnected are replayed on resume.
+	_, err = apiClient.MergePullRequest(ctx, "stream-pr")
+	require.NoError(t, err)
+
+	resp, received, closeStream = openStream(t, "user_id=stream2", strconv.FormatInt(created.EventId, 10))
+	defer closeStream()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	merged := receiveEvent(t, received)
+	require.Equal(t, openapi.Merged, merged.EventType)
+	require.Equal(t, "stream-pr", merged.PullRequestId)
+}
@@ -0,0 +1,144 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/webhook"
+	"avito-trainee-task/internal/worker"
+	"avito-trainee-task/pkg/client"
+	"avito-trainee-task/pkg/client/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	const secret = "webhook-test-secret"
+	ctx := context.Background()
+
+	received := make(chan receivedWebhook, 10)
+	var failures atomic.Int32
+	failures.Store(1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{header: r.Header.Clone(), body: body}
+	}))
+	defer receiver.Close()
+
+	lead := openapi.Lead
+	_, err := apiClient.AddTeam(client.WithActorContext(ctx, adminId), client.Team{
+		TeamName: "webhook-admins",
+		Members: []client.TeamMember{
+			{UserId: "wh-lead", Username: "wh-lead", IsActive: true, Role: &lead},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = apiClient.AddTeam(ctx, client.Team{
+		TeamName: "webhooks",
+		Members: []client.TeamMember{
+			{UserId: "wh1", Username: "wh1", IsActive: true},
+			{UserId: "wh2", Username: "wh2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	req := client.SubscribeWebhookRequest{
+		Url:        receiver.URL,
+		Secret:     secret,
+		EventTypes: &[]client.WebhookEventType{openapi.PrCreated},
+	}
+	_, err = apiClient.SubscribeWebhook(ctx, req)
+	require.ErrorIs(t, err, client.ErrForbidden)
+
+	ctx = client.WithActorContext(ctx, "wh-lead")
+	sub, err := apiClient.SubscribeWebhook(ctx, req)
+	require.NoError(t, err)
+	defer func() {
+		_, err := apiClient.UnsubscribeWebhook(ctx, sub.SubscriptionId)
+		require.NoError(t, err)
+	}()
+
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "wh-pr1",
+		PullRequestName: "webhooks",
+		AuthorId:        "wh1",
+	})
+	require.NoError(t, err)
+
+	dispatcher := webhook.NewDispatcher(storage, webhook.Config{
+		Config: worker.Config{
+			BatchSize:   10,
+			MaxAttempts: 3,
+			MinBackoff:  0,
+			MaxBackoff:  0,
+			Timeout:     5 * time.Second,
+		},
+		AllowPrivateTargets: true,
+	})
+
+	n, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	pending := openapi.Pending
+	deliveries, err := apiClient.ListWebhookDeliveries(ctx, client.ListWebhookDeliveriesParams{Status: &pending})
+	require.NoError(t, err)
+	require.Len(t, deliveries.Deliveries, 1)
+	require.Equal(t, 1, deliveries.Deliveries[0].Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, *deliveries.Deliveries[0].LastStatusCode)
+
+	_, err = apiClient.RedeliverWebhook(ctx, deliveries.Deliveries[0].DeliveryId)
+	require.NoError(t, err)
+
+	n, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	hook := <-received
+	require.Equal(t, string(openapi.PrCreated), hook.header.Get(webhook.EventHeader))
+	require.Equal(t, webhook.Sign(secret, hook.body), hook.header.Get(webhook.SignatureHeader))
+
+	var payload client.WebhookPayload
+	require.NoError(t, json.Unmarshal(hook.body, &payload))
+	require.Equal(t, openapi.PrCreated, payload.EventType)
+	require.Equal(t, "wh-pr1", payload.Data.PullRequestId)
+	require.Equal(t, []string{"wh2"}, payload.Data.Reviewers)
+
+	_, err = apiClient.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "wh-pr2",
+		PullRequestName: "webhooks",
+		AuthorId:        "wh1",
+	})
+	require.NoError(t, err)
+
+	strict := webhook.NewDispatcher(storage, webhook.Config{Config: worker.Config{
+		BatchSize:   10,
+		MaxAttempts: 3,
+		Timeout:     5 * time.Second,
+	}})
+	n, err = strict.DispatchOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Empty(t, received)
+
+	deliveries, err = apiClient.ListWebhookDeliveries(ctx, client.ListWebhookDeliveriesParams{Status: &pending})
+	require.NoError(t, err)
+	require.Len(t, deliveries.Deliveries, 1)
+	require.Contains(t, *deliveries.Deliveries[0].LastError, "not allowed")
+}
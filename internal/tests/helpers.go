@@ -15,6 +15,8 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	pg "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func CreateTestPool(ctx context.Context, migrationPath string) (*pgxpool.Pool, error) {
@@ -57,10 +59,13 @@ func CreatePostgresStorage(ctx context.Context, migrationPath string) (*postgres
 	return postgres.NewWithPool(pool), nil
 }
 
-func StartServer(s v1.Storage) (string, func(), error) {
+func StartServer(s v1.Storage, register ...func(e *echo.Echo)) (string, func(), error) {
 	e := echo.New()
 	h := v1.NewHandler(s)
 	h.RegisterRoutes(e)
+	for _, r := range register {
+		r(e)
+	}
 
 	listener, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
@@ -76,13 +81,10 @@ func StartServer(s v1.Storage) (string, func(), error) {
 		}
 	}()
 
-	time.Sleep(2 * time.Second)
-
-	resp, err := http.Get(serverURL + "/")
-	if err != nil {
-		return "", nil, fmt.Errorf("server not responding: %v", err)
+	if err := waitReady(serverURL, 5*time.Second); err != nil {
+		_ = e.Close()
+		return "", nil, err
 	}
-	resp.Body.Close()
 
 	cleanup := func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -92,3 +94,55 @@ func StartServer(s v1.Storage) (string, func(), error) {
 
 	return serverURL, cleanup, nil
 }
+
+// waitReady polls /readyz until the server reports ready.
+func waitReady(serverURL string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := http.Get(serverURL + "/readyz")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("status %d", resp.StatusCode)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("server not ready: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// StartGRPCServer serves the registered services on a local port and
+// returns a client connection to it.
+func StartGRPCServer(register ...func(s *grpc.Server)) (*grpc.ClientConn, func(), error) {
+	srv := grpc.NewServer()
+	for _, r := range register {
+		r(srv)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen: %v", err)
+	}
+
+	go func() {
+		if err := srv.Serve(listener); err != nil {
+			log.Printf("gRPC server error: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		srv.Stop()
+		return nil, nil, fmt.Errorf("failed to create gRPC client: %v", err)
+	}
+
+	cleanup := func() {
+		conn.Close()
+		srv.Stop()
+	}
+
+	return conn, cleanup, nil
+}
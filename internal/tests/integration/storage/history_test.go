@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"testing"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestHistory(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('reviewer2', 'charlie', 'backend', true),
+			('reviewer3', 'dave', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	pr, err := storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "author1",
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+	}, "author1")
+	require.NoError(t, err)
+
+	reason := "on vacation"
+	oldReviewer := pr.AssignedReviewers[0]
+	_, newReviewer, err := storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "pr1",
+		OldUserId:     oldReviewer,
+		Reason:        &reason,
+	}, oldReviewer)
+	require.NoError(t, err)
+
+	_, err = storage.Merge(ctx, "pr1", "author1")
+	require.NoError(t, err)
+	_, err = storage.Merge(ctx, "pr1", "author1")
+	require.NoError(t, err)
+
+	order := api.Asc
+	history, err := storage.GetPullRequestHistory(ctx, api.GetPullRequestHistoryParams{
+		PullRequestId: "pr1",
+		Order:         &order,
+	})
+	require.NoError(t, err)
+	require.Len(t, history.Events, 3)
+
+	created, declined, merged := history.Events[0], history.Events[1], history.Events[2]
+	require.Equal(t, api.Created, created.EventType)
+	require.Equal(t, "author1", *created.ActorId)
+	require.Equal(t, pr.AssignedReviewers, created.Reviewers)
+
+	require.Equal(t, api.Declined, declined.EventType)
+	require.Equal(t, oldReviewer, *declined.OldUserId)
+	require.Equal(t, newReviewer, *declined.NewUserId)
+	require.Equal(t, reason, *declined.Reason)
+	require.NotContains(t, declined.Reviewers, oldReviewer)
+
+	require.Equal(t, api.Merged, merged.EventType)
+	require.Nil(t, merged.Reason)
+
+	limit := 2
+	page, err := storage.GetPullRequestHistory(ctx, api.GetPullRequestHistoryParams{
+		PullRequestId: "pr1",
+		Limit:         &limit,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 2)
+	require.Equal(t, api.Merged, page.Events[0].EventType)
+	require.NotNil(t, page.NextCursor)
+
+	page, err = storage.GetPullRequestHistory(ctx, api.GetPullRequestHistoryParams{
+		PullRequestId: "pr1",
+		Limit:         &limit,
+		Cursor:        page.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	require.Equal(t, api.Created, page.Events[0].EventType)
+	require.Nil(t, page.NextCursor)
+
+	_, err = storage.GetPullRequestHistory(ctx, api.GetPullRequestHistoryParams{PullRequestId: "NONEXISTENT"})
+	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
+}
+
+func TestUserHistory(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active, role) VALUES
+			('author1', 'alice', 'backend', true, 'member'),
+			('reviewer1', 'bob', 'backend', true, 'member'),
+			('reviewer2', 'charlie', 'backend', true, 'member'),
+			('lead1', 'dave', 'backend', true, 'lead');
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'author1', '{"reviewer1"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	_, err = storage.DeactivateTeamMembers(ctx, "backend", []string{"reviewer1"}, "lead1")
+	require.NoError(t, err)
+
+	history, err := storage.GetUserHistory(ctx, api.GetUsersHistoryParams{UserId: "reviewer1"})
+	require.NoError(t, err)
+	require.Len(t, history.Events, 1)
+
+	event := history.Events[0]
+	require.Equal(t, api.DeactivatedReplaced, event.EventType)
+	require.Equal(t, "lead1", *event.ActorId)
+	require.Equal(t, "reviewer1", *event.OldUserId)
+	require.NotNil(t, event.NewUserId)
+	require.Equal(t, []string{*event.NewUserId}, event.Reviewers)
+
+	history, err = storage.GetUserHistory(ctx, api.GetUsersHistoryParams{UserId: "lead1"})
+	require.NoError(t, err)
+	require.Len(t, history.Events, 1)
+
+	_, err = storage.GetUserHistory(ctx, api.GetUsersHistoryParams{UserId: "NONEXISTENT"})
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"testing"
+
+	"avito-trainee-task/internal/api"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTeams(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'bob', 'frontend', true),
+			('user3', 'charlie', 'backend', true),
+			('user4', 'dave', 'backend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'user1', '{"user3"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	lead := api.Lead
+	teams := []api.Team{{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "user1", Username: "alice", IsActive: true, Role: &lead},
+			{UserId: "user2", Username: "bob", IsActive: true},
+			{UserId: "user4", Username: "dave", IsActive: true},
+			{UserId: "user5", Username: "eve", IsActive: true},
+		},
+	}}
+
+	dryRun, apply, deactivate := true, false, true
+	report, err := storage.ImportTeams(ctx, teams, api.PostImportParams{DryRun: &dryRun})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Empty(t, report.Deactivated)
+
+	params := api.PostImportParams{DryRun: &dryRun, DeactivateMissing: &deactivate}
+	report, err = storage.ImportTeams(ctx, teams, params)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, []string{"user5"}, report.Created)
+	require.Equal(t, []string{"user1"}, report.Updated)
+	require.Equal(t, []api.ImportMove{{UserId: "user2", FromTeam: "frontend", ToTeam: "backend"}}, report.Moved)
+	require.Equal(t, []string{"user3"}, report.Deactivated)
+	require.Empty(t, report.Reassignments)
+
+	var count int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM users WHERE user_id = 'user5'").Scan(&count)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	params.DryRun = &apply
+	report, err = storage.ImportTeams(ctx, teams, params)
+	require.NoError(t, err)
+	require.False(t, report.DryRun)
+	require.Len(t, report.Reassignments, 1)
+	require.Equal(t, "user3", report.Reassignments[0].OldUserId)
+	require.NotNil(t, report.Reassignments[0].ReplacedBy)
+
+	team, err := storage.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 5)
+
+	var isActive bool
+	err = tx.QueryRow(ctx, "SELECT is_active FROM users WHERE user_id = 'user3'").Scan(&isActive)
+	require.NoError(t, err)
+	require.False(t, isActive)
+
+	ok, err := storage.IsTeamLead(ctx, "user1", "backend")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	params.DryRun = &dryRun
+	report, err = storage.ImportTeams(ctx, teams, params)
+	require.NoError(t, err)
+	require.Empty(t, report.Created)
+	require.Empty(t, report.Updated)
+	require.Empty(t, report.Moved)
+	require.Empty(t, report.Deactivated)
+}
+
+func TestImportTeamsKeepsMissing(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'bob', 'frontend', true);
+		`)
+	require.NoError(t, err)
+
+	apply := false
+	teams := []api.Team{{
+		TeamName: "backend",
+		Members:  []api.TeamMember{{UserId: "user1", Username: "alice", IsActive: true}},
+	}}
+	report, err := storage.ImportTeams(ctx, teams, api.PostImportParams{DryRun: &apply})
+	require.NoError(t, err)
+	require.Empty(t, report.Deactivated)
+
+	var isActive bool
+	err = tx.QueryRow(ctx, "SELECT is_active FROM users WHERE user_id = 'user2'").Scan(&isActive)
+	require.NoError(t, err)
+	require.True(t, isActive)
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeHostAccounts(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('u1', 'alice', 'backend', true),
+			('u2', 'bob', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	account, err := storage.SetCodeHostAccount(ctx, api.CodeHostAccount{
+		Provider: api.Github,
+		Login:    "Alice-GH",
+		UserId:   "u1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "alice-gh", account.Login)
+
+	userId, err := storage.GetCodeHostUserId(ctx, api.Github, "ALICE-gh")
+	require.NoError(t, err)
+	require.Equal(t, "u1", userId)
+
+	_, err = storage.SetCodeHostAccount(ctx, api.CodeHostAccount{
+		Provider: api.Github,
+		Login:    "alice-gh",
+		UserId:   "u2",
+	})
+	require.NoError(t, err)
+	userId, err = storage.GetCodeHostUserId(ctx, api.Github, "alice-gh")
+	require.NoError(t, err)
+	require.Equal(t, "u2", userId)
+
+	_, err = storage.SetCodeHostAccount(ctx, api.CodeHostAccount{
+		Provider: api.Github,
+		Login:    "ghost",
+		UserId:   "missing",
+	})
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+
+	provider := api.Github
+	accounts, err := storage.ListCodeHostAccounts(ctx, &provider)
+	require.NoError(t, err)
+	require.Equal(t, []api.CodeHostAccount{{Provider: api.Github, Login: "alice-gh", UserId: "u2"}}, accounts)
+
+	_, err = storage.DeleteCodeHostAccount(ctx, api.Github, "Alice-GH")
+	require.NoError(t, err)
+	_, err = storage.GetCodeHostUserId(ctx, api.Github, "alice-gh")
+	require.ErrorIs(t, err, postgres.ErrAccountNotFound)
+	_, err = storage.DeleteCodeHostAccount(ctx, api.Github, "alice-gh")
+	require.ErrorIs(t, err, postgres.ErrAccountNotFound)
+}
+
+func TestExternalPullRequestLink(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('u1', 'alice', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	_, err = storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "u1",
+		PullRequestId:   "github:acme/backend#1",
+		PullRequestName: "Test PR",
+	}, "u1")
+	require.NoError(t, err)
+
+	link := postgres.ExternalPullRequest{
+		Provider:      api.Github,
+		Repository:    "acme/backend",
+		Number:        1,
+		PullRequestId: "github:acme/backend#1",
+	}
+	require.NoError(t, storage.LinkExternalPullRequest(ctx, link))
+	require.NoError(t, storage.LinkExternalPullRequest(ctx, link))
+
+	prId, err := storage.GetExternalPullRequestId(ctx, api.Github, "acme/backend", 1)
+	require.NoError(t, err)
+	require.Equal(t, link.PullRequestId, prId)
+
+	_, err = storage.GetExternalPullRequestId(ctx, api.Github, "acme/backend", 2)
+	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
+}
+
+func TestReviewerSync(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('reviewer2', 'charlie', 'backend', true),
+			('reviewer3', 'dave', 'backend', true)
+		`)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status)
+			VALUES
+			('pr1', 'Test PR', 'author1', '{"reviewer1","reviewer2"}', 'OPEN'),
+			('pr2', 'Other PR', 'author1', '{"reviewer1"}', 'OPEN'),
+			('pr3', 'Unlinked PR', 'author1', '{"reviewer1"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+	for login, userId := range map[string]string{"bob-gh": "reviewer1", "dave-gh": "reviewer3"} {
+		_, err = storage.SetCodeHostAccount(ctx, api.CodeHostAccount{
+			Provider: api.Github,
+			Login:    login,
+			UserId:   userId,
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, storage.LinkExternalPullRequest(ctx, postgres.ExternalPullRequest{
+		Provider:      api.Github,
+		Repository:    "acme/backend",
+		Number:        1,
+		PullRequestId: "pr1",
+	}))
+	require.NoError(t, storage.LinkExternalPullRequest(ctx, postgres.ExternalPullRequest{
+		Provider:      api.Gitlab,
+		Repository:    "acme/backend",
+		Number:        2,
+		PullRequestId: "pr2",
+	}))
+
+	sync, err := storage.GetReviewerSync(ctx, "pr3")
+	require.NoError(t, err)
+	require.Nil(t, sync)
+
+	pending, err := storage.ClaimReviewerSyncs(ctx, []api.CodeHostProvider{api.Github}, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, "pr1", pending[0].PullRequestId)
+	require.Equal(t, "acme/backend", pending[0].Repository)
+	require.Equal(t, 1, pending[0].Number)
+	require.Equal(t, []string{"bob-gh"}, pending[0].Reviewers)
+	require.Empty(t, pending[0].SyncedReviewers)
+
+	again, err := storage.ClaimReviewerSyncs(ctx, []api.CodeHostProvider{api.Github}, 10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	require.NoError(t, storage.MarkReviewerSynced(ctx, "pr1", pending[0].Version, pending[0].Reviewers))
+	sync, err = storage.GetReviewerSync(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, api.SyncSynced, sync.Status)
+	require.NotNil(t, sync.SyncedAt)
+
+	_, _, err = storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "pr1",
+		OldUserId:     "reviewer1",
+	}, "")
+	require.NoError(t, err)
+
+	pending, err = storage.ClaimReviewerSyncs(ctx, []api.CodeHostProvider{api.Github}, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, []string{"bob-gh"}, pending[0].SyncedReviewers)
+	require.Equal(t, []string{"dave-gh"}, pending[0].Reviewers)
+
+	// A failure recorded for a stale version is dropped.
+	require.NoError(t, storage.MarkReviewerSyncFailed(ctx, "pr1", pending[0].Version-1, "stale", nil))
+	sync, err = storage.GetReviewerSync(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, api.SyncPending, sync.Status)
+
+	require.NoError(t, storage.MarkReviewerSyncFailed(ctx, "pr1", pending[0].Version, "not a collaborator", nil))
+	sync, err = storage.GetReviewerSync(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, api.SyncFailed, sync.Status)
+	require.Equal(t, 1, sync.Attempts)
+	require.Equal(t, "not a collaborator", *sync.LastError)
+
+	sync, err = storage.RetryReviewerSync(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, api.SyncPending, sync.Status)
+	require.Zero(t, sync.Attempts)
+	require.Nil(t, sync.LastError)
+
+	_, err = storage.RetryReviewerSync(ctx, "pr3")
+	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
+}
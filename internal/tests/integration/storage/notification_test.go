@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationContacts(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('u1', 'alice', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	channel, empty := "#reviews", ""
+	contact, err := storage.SetNotificationContact(ctx, api.NotificationContact{
+		UserId:      "u1",
+		ChatChannel: &channel,
+		ChatHandle:  &empty,
+	})
+	require.NoError(t, err)
+	require.Equal(t, channel, *contact.ChatChannel)
+	require.Nil(t, contact.ChatHandle)
+
+	_, err = storage.SetNotificationContact(ctx, api.NotificationContact{UserId: "missing", ChatChannel: &channel})
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+
+	contacts, err := storage.ListNotificationContacts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []api.NotificationContact{*contact}, contacts)
+
+	_, err = storage.DeleteNotificationContact(ctx, "u1")
+	require.NoError(t, err)
+	_, err = storage.DeleteNotificationContact(ctx, "u1")
+	require.ErrorIs(t, err, postgres.ErrContactNotFound)
+}
+
+func TestNotificationQueue(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('reviewer2', 'charlie', 'backend', true)
+		`)
+	require.NoError(t, err)
+	for _, id := range []string{"author1", "reviewer1", "reviewer2"} {
+		handle := id
+		_, err = storage.SetNotificationContact(ctx, api.NotificationContact{UserId: id, ChatHandle: &handle})
+		require.NoError(t, err)
+	}
+
+	_, err = storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "author1",
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+	}, "author1")
+	require.NoError(t, err)
+
+	pending, err := storage.ClaimNotifications(ctx, postgres.ChatTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	recipients := []string{pending[0].Contact.UserId, pending[1].Contact.UserId}
+	require.ElementsMatch(t, []string{"reviewer1", "reviewer2"}, recipients)
+	require.Equal(t, api.Created, pending[0].Event.EventType)
+	require.Equal(t, "Test PR", pending[0].PullRequestName)
+	require.Equal(t, pending[0].Contact.UserId, *pending[0].Contact.ChatHandle)
+
+	again, err := storage.ClaimNotifications(ctx, postgres.ChatTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	require.NoError(t, storage.MarkNotificationSent(ctx, pending[0].NotificationId))
+	require.NoError(t, storage.MarkNotificationFailed(ctx, pending[1].NotificationId, "channel_not_found", nil))
+
+	// The actor is not notified of own actions.
+	_, err = storage.Merge(ctx, "pr1", "reviewer1")
+	require.NoError(t, err)
+
+	pending, err = storage.ClaimNotifications(ctx, postgres.ChatTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, "reviewer2", pending[0].Contact.UserId)
+	require.Equal(t, api.Merged, pending[0].Event.EventType)
+
+	var statuses []string
+	err = tx.QueryRow(ctx, `SELECT array_agg(status::text ORDER BY notification_id) FROM notifications`).Scan(&statuses)
+	require.NoError(t, err)
+	require.Equal(t, []string{"delivered", "dead", "pending"}, statuses)
+}
+
+func TestNotificationQueueTransports(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	handle := "bob"
+	email := openapi_types.Email("bob@example.com")
+	_, err = storage.SetNotificationContact(ctx, api.NotificationContact{
+		UserId:     "reviewer1",
+		ChatHandle: &handle,
+		Email:      &email,
+	})
+	require.NoError(t, err)
+
+	// Only the email notifier runs, chat notifications are not queued.
+	storage.SetNotificationTransports(postgres.EmailTransport)
+	_, err = storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "author1",
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+	}, "author1")
+	require.NoError(t, err)
+
+	chat, err := storage.ClaimNotifications(ctx, postgres.ChatTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, chat)
+
+	pending, err := storage.ClaimNotifications(ctx, postgres.EmailTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+}
+
+func TestEmailNotificationQueue(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('reviewer2', 'charlie', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	handle := "bob"
+	email1 := openapi_types.Email("bob@example.com")
+	_, err = storage.SetNotificationContact(ctx, api.NotificationContact{
+		UserId:     "reviewer1",
+		ChatHandle: &handle,
+		Email:      &email1,
+	})
+	require.NoError(t, err)
+	email2 := openapi_types.Email("charlie@example.com")
+	contact, err := storage.SetNotificationContact(ctx, api.NotificationContact{
+		UserId:      "reviewer2",
+		Email:       &email2,
+		EmailOptOut: &[]api.NotificationKind{api.KindMerged},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []api.NotificationKind{api.KindMerged}, *contact.EmailOptOut)
+
+	_, err = storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "author1",
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+	}, "author1")
+	require.NoError(t, err)
+
+	chat, err := storage.ClaimNotifications(ctx, postgres.ChatTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, chat, 1)
+	require.Equal(t, "reviewer1", chat[0].Contact.UserId)
+
+	pending, err := storage.ClaimNotifications(ctx, postgres.EmailTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	for _, n := range pending {
+		require.Equal(t, api.KindAssigned, n.Kind)
+		require.NotNil(t, n.Contact.Email)
+		require.NoError(t, storage.MarkNotificationSent(ctx, n.NotificationId))
+	}
+
+	// Reviewers are reminded of open pull requests once per period.
+	reminders, err := storage.ClaimEmailReminders(ctx, time.Hour, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	require.Equal(t, "reviewer1", reminders[0].UserId)
+	require.Equal(t, "bob@example.com", reminders[0].Email)
+	require.Len(t, reminders[0].PullRequests, 1)
+	require.Equal(t, "pr1", reminders[0].PullRequests[0].PullRequestId)
+
+	reminders, err = storage.ClaimEmailReminders(ctx, time.Hour, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, reminders)
+
+	// Merge emails respect the opt-out of reviewer2.
+	_, err = storage.Merge(ctx, "pr1", "author1")
+	require.NoError(t, err)
+
+	pending, err = storage.ClaimNotifications(ctx, postgres.EmailTransport, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, "reviewer1", pending[0].Contact.UserId)
+	require.Equal(t, api.KindMerged, pending[0].Kind)
+}
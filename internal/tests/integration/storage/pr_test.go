@@ -23,7 +23,7 @@ func TestMerge(t *testing.T) {
 			VALUES ('pr1', 'Test PR', 'author1', '{"rev1"}', 'OPEN')`)
 	require.NoError(t, err)
 
-	result, err := storage.Merge(ctx, "pr1")
+	result, err := storage.Merge(ctx, "pr1", "")
 	require.NoError(t, err)
 
 	require.Equal(t, "pr1", result.PullRequestId)
@@ -43,7 +43,7 @@ func TestMergeNonExistentPR(t *testing.T) {
 	_, storage, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	pr, err := storage.Merge(ctx, "NONEXISTENT")
+	pr, err := storage.Merge(ctx, "NONEXISTENT", "")
 	require.Nil(t, pr)
 	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
 }
@@ -65,7 +65,7 @@ func TestMergeMergedPR(t *testing.T) {
 		mergedTime)
 	require.NoError(t, err)
 
-	mergedPR, err := storage.Merge(ctx, "pr1")
+	mergedPR, err := storage.Merge(ctx, "pr1", "")
 	require.NoError(t, err)
 
 	require.True(t, mergedPR.MergedAt.After(mergedTime))
@@ -153,6 +153,32 @@ func TestGetNonExistentPR(t *testing.T) {
 	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
 }
 
+func TestGetPRById(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES
+		('author1', 'author', 'backend', true);
+		INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status)
+			VALUES ('pr1', 'Test PR', 'author1', '{"rev1"}', 'OPEN');
+		UPDATE pull_requests SET status = 'MERGED' WHERE pull_request_id = 'pr1'`)
+	require.NoError(t, err)
+
+	pr, err := storage.GetPullRequestById(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, "pr1", pr.PullRequestId)
+	require.Equal(t, []string{"rev1"}, pr.AssignedReviewers)
+	require.Equal(t, api.PullRequestStatusMERGED, pr.Status)
+	require.NotNil(t, pr.CreatedAt)
+	require.NotNil(t, pr.MergedAt)
+
+	pr, err = storage.GetPullRequestById(ctx, "NONEXISTENT")
+	require.Nil(t, pr)
+	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
+}
+
 func TestCreatePR(t *testing.T) {
 	tx, storage, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -171,7 +197,7 @@ func TestCreatePR(t *testing.T) {
 		AuthorId:        "author1",
 	}
 
-	act, err := storage.CreatePullRequest(ctx, exp)
+	act, err := storage.CreatePullRequest(ctx, exp, "")
 	require.NoError(t, err)
 
 	require.Equal(t, exp.PullRequestId, act.PullRequestId)
@@ -217,7 +243,10 @@ func TestReassign(t *testing.T) {
 		`)
 	require.NoError(t, err)
 
-	actual, newRev, err := storage.Reassign(ctx, "pr1", "reviewer1")
+	actual, newRev, err := storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "pr1",
+		OldUserId:     "reviewer1",
+	}, "")
 	require.NoError(t, err)
 
 	require.Equal(t, "pr1", actual.PullRequestId)
@@ -230,7 +259,10 @@ func TestReassignNonExistent(t *testing.T) {
 	_, storage, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	pr, newReviewer, err := storage.Reassign(ctx, "NONEXISTENT", "user1")
+	pr, newReviewer, err := storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "NONEXISTENT",
+		OldUserId:     "user1",
+	}, "")
 	require.Nil(t, pr)
 	require.Empty(t, newReviewer)
 	require.ErrorIs(t, err, postgres.ErrPullRequestNotFound)
@@ -251,13 +283,16 @@ func TestReassignMerged(t *testing.T) {
 		AuthorId:        "author1",
 		PullRequestId:   "pr1",
 		PullRequestName: "Test PR",
-	})
+	}, "")
 	require.NoError(t, err)
 
-	_, err = storage.Merge(ctx, "pr1")
+	_, err = storage.Merge(ctx, "pr1", "")
 	require.NoError(t, err)
 
-	pr, newReviewer, err := storage.Reassign(ctx, "pr1", "reviewer1")
+	pr, newReviewer, err := storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "pr1",
+		OldUserId:     "reviewer1",
+	}, "")
 	require.Nil(t, pr)
 	require.Empty(t, newReviewer)
 	require.ErrorIs(t, err, postgres.ErrReassignMergedPullRequest)
@@ -278,10 +313,13 @@ func TestReassignUserNotAReviewer(t *testing.T) {
 		AuthorId:        "author1",
 		PullRequestId:   "pr1",
 		PullRequestName: "Test PR",
-	})
+	}, "")
 	require.NoError(t, err)
 
-	pr, newRev, err := storage.Reassign(ctx, "pr1", "NONEXISTENTUSER")
+	pr, newRev, err := storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "pr1",
+		OldUserId:     "NONEXISTENTUSER",
+	}, "")
 	require.Nil(t, pr)
 	require.Empty(t, newRev)
 	require.ErrorIs(t, err, postgres.ErrUserNotAReviewer)
@@ -302,11 +340,86 @@ func TestReassignNoCandidate(t *testing.T) {
 		AuthorId:        "author1",
 		PullRequestId:   "pr1",
 		PullRequestName: "Test PR",
-	})
+	}, "")
 	require.NoError(t, err)
 
-	pr, newRev, err := storage.Reassign(ctx, "pr1", "reviewer1")
+	pr, newRev, err := storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "pr1",
+		OldUserId:     "reviewer1",
+	}, "")
 	require.Nil(t, pr)
 	require.Empty(t, newRev)
 	require.ErrorIs(t, err, postgres.ErrNoCandidate)
 }
+
+func TestListPullRequests(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('author2', 'bob', 'frontend', true),
+			('rev1', 'charlie', 'backend', true);
+		INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt, mergedAt) VALUES
+			('pr1', 'PR 1', 'author1', '{"rev1"}', 'OPEN', '2025-01-01 10:00:00', NULL),
+			('pr2', 'PR 2', 'author1', '{}', 'MERGED', '2025-01-02 10:00:00', '2025-01-03 10:00:00'),
+			('pr3', 'PR 3', 'author2', '{"rev1"}', 'OPEN', '2025-01-03 10:00:00', NULL)`)
+	require.NoError(t, err)
+
+	ids := func(list *api.PullRequestList) []string {
+		result := make([]string, len(list.PullRequests))
+		for i, pr := range list.PullRequests {
+			result[i] = pr.PullRequestId
+		}
+		return result
+	}
+
+	list, err := storage.ListPullRequests(ctx, api.GetPullRequestListParams{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr3", "pr2", "pr1"}, ids(list))
+	require.Nil(t, list.NextCursor)
+
+	status := api.GetPullRequestListParamsStatusOPEN
+	team := "backend"
+	list, err = storage.ListPullRequests(ctx, api.GetPullRequestListParams{Status: &status, TeamName: &team})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr1"}, ids(list))
+
+	reviewer := "rev1"
+	from := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	list, err = storage.ListPullRequests(ctx, api.GetPullRequestListParams{ReviewerId: &reviewer, CreatedFrom: &from})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr3"}, ids(list))
+
+	sort, order := api.MergedAt, api.Asc
+	limit := 2
+	list, err = storage.ListPullRequests(ctx, api.GetPullRequestListParams{Sort: &sort, Order: &order, Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr2", "pr1"}, ids(list))
+	require.NotNil(t, list.NextCursor)
+
+	list, err = storage.ListPullRequests(ctx, api.GetPullRequestListParams{
+		Sort:   &sort,
+		Order:  &order,
+		Limit:  &limit,
+		Cursor: list.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr3"}, ids(list))
+	require.Nil(t, list.NextCursor)
+}
+
+func TestListPullRequestsInvalidParams(t *testing.T) {
+	_, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	cursor := "not a cursor"
+	_, err := storage.ListPullRequests(ctx, api.GetPullRequestListParams{Cursor: &cursor})
+	require.ErrorIs(t, err, postgres.ErrInvalidCursor)
+
+	status := api.GetPullRequestListParamsStatus("CLOSED")
+	_, err = storage.ListPullRequests(ctx, api.GetPullRequestListParams{Status: &status})
+	require.ErrorIs(t, err, postgres.ErrInvalidListParams)
+}
@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersStats(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('stats-a1', 'alice', 'stats-backend', true),
+			('stats-r1', 'bob', 'stats-backend', true),
+			('stats-r2', 'charlie', 'stats-backend', true),
+			('stats-r4', 'erin', 'stats-backend', true),
+			('stats-a2', 'dave', 'stats-frontend', true),
+			('stats-r3', 'frank', 'stats-frontend', true)
+		`)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt, mergedAt) VALUES
+			('stats-old', 'Old', 'stats-a1', '{stats-r1}', 'MERGED', '2025-01-05', '2025-01-10'),
+			('stats-jan', 'January', 'stats-a1', '{stats-r1,stats-r2}', 'OPEN', '2025-01-20', NULL),
+			('stats-feb', 'February', 'stats-a2', '{stats-r3}', 'MERGED', '2025-01-25', '2025-02-03')
+		`)
+	require.NoError(t, err)
+
+	pr, err := storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "stats-a1",
+		PullRequestId:   "stats-now",
+		PullRequestName: "Now",
+	}, "stats-a1")
+	require.NoError(t, err)
+	_, _, err = storage.Reassign(ctx, api.PostPullRequestReassignJSONBody{
+		PullRequestId: "stats-now",
+		OldUserId:     pr.AssignedReviewers[0],
+	}, "")
+	require.NoError(t, err)
+
+	at := func(s string) *time.Time {
+		day, err := time.Parse(time.DateOnly, s)
+		require.NoError(t, err)
+		return &day
+	}
+
+	// Created in the window.
+	stats, err := storage.GetUsersStats(ctx, api.GetUsersStatsParams{From: at("2025-01-15"), To: at("2025-02-01")})
+	require.NoError(t, err)
+	require.Equal(t, []api.AssignmentCount{
+		{UserId: "stats-r1", AssignmentCount: 1, OpenCount: 1},
+		{UserId: "stats-r2", AssignmentCount: 1, OpenCount: 1},
+		{UserId: "stats-r3", AssignmentCount: 1, MergedCount: 1},
+	}, stats.Stats)
+	require.Equal(t, []api.TeamStat{
+		{TeamName: "stats-backend", PullRequestCount: 1, OpenCount: 1},
+		{TeamName: "stats-frontend", PullRequestCount: 1, MergedCount: 1},
+	}, stats.Teams)
+	require.Equal(t, []api.AuthorStat{
+		{UserId: "stats-a1", PullRequestCount: 1, OpenCount: 1},
+		{UserId: "stats-a2", PullRequestCount: 1, MergedCount: 1},
+	}, stats.Authors)
+	require.Len(t, stats.PullRequests, 2)
+
+	// Merged in the window.
+	stats, err = storage.GetUsersStats(ctx, api.GetUsersStatsParams{From: at("2025-02-01"), To: at("2025-03-01")})
+	require.NoError(t, err)
+	require.Equal(t, []api.PullRequestStat{
+		{PullRequestId: "stats-feb", AuthorId: "stats-a2", Status: api.PullRequestStatStatusMERGED, ReviewerCount: 1},
+	}, stats.PullRequests)
+
+	// Without bounds every pull request is counted.
+	stats, err = storage.GetUsersStats(ctx, api.GetUsersStatsParams{})
+	require.NoError(t, err)
+	require.Nil(t, stats.From)
+	require.Equal(t, api.PullRequestStat{
+		PullRequestId:     "stats-now",
+		AuthorId:          "stats-a1",
+		Status:            api.PullRequestStatStatusOPEN,
+		ReviewerCount:     2,
+		ReassignmentCount: 1,
+	}, stats.PullRequests[0])
+	require.Contains(t, stats.Teams, api.TeamStat{
+		TeamName:          "stats-backend",
+		PullRequestCount:  3,
+		OpenCount:         2,
+		MergedCount:       1,
+		ReassignmentCount: 1,
+	})
+
+	// The pull request table keeps the ones with the most replacements.
+	limit := 1
+	stats, err = storage.GetUsersStats(ctx, api.GetUsersStatsParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, stats.PullRequests, 1)
+	require.Equal(t, "stats-now", stats.PullRequests[0].PullRequestId)
+	require.Contains(t, stats.Teams, api.TeamStat{
+		TeamName:          "stats-backend",
+		PullRequestCount:  3,
+		OpenCount:         2,
+		MergedCount:       1,
+		ReassignmentCount: 1,
+	})
+
+	_, err = storage.GetUsersStats(ctx, api.GetUsersStatsParams{From: at("2025-02-01"), To: at("2025-02-01")})
+	require.ErrorIs(t, err, postgres.ErrInvalidListParams)
+}
+
+func TestTimeToMerge(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('ttm-a1', 'alice', 'ttm-backend', true),
+			('ttm-r1', 'bob', 'ttm-backend', true),
+			('ttm-r2', 'charlie', 'ttm-backend', true),
+			('ttm-a2', 'dave', 'ttm-frontend', true),
+			('ttm-r3', 'erin', 'ttm-frontend', true)
+		`)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt, mergedAt) VALUES
+			('ttm-1', 'One', 'ttm-a1', '{ttm-r1}', 'MERGED', '2025-03-03 00:00', '2025-03-03 01:00'),
+			('ttm-2', 'Two', 'ttm-a1', '{ttm-r1,ttm-r2}', 'MERGED', '2025-03-04 00:00', '2025-03-04 03:00'),
+			('ttm-3', 'Three', 'ttm-a1', '{ttm-r2}', 'MERGED', '2025-03-05 00:00', '2025-03-05 05:00'),
+			('ttm-4', 'Four', 'ttm-a1', '{ttm-r1}', 'MERGED', '2025-03-08 00:00', '2025-03-11 00:00'),
+			('ttm-5', 'Five', 'ttm-a2', '{ttm-r3}', 'MERGED', '2025-03-03 00:00', '2025-03-03 02:00'),
+			('ttm-open', 'Open', 'ttm-a1', '{ttm-r1}', 'OPEN', '2025-03-03 00:00', NULL)
+		`)
+	require.NoError(t, err)
+
+	at := func(s string) *time.Time {
+		day, err := time.Parse(time.DateOnly, s)
+		require.NoError(t, err)
+		return &day
+	}
+	first, second := *at("2025-03-03"), *at("2025-03-10")
+
+	stat, err := storage.GetTimeToMerge(ctx, api.GetStatsTimeToMergeParams{From: at("2025-03-01"), To: at("2025-04-01")})
+	require.NoError(t, err)
+	require.Equal(t, []api.TeamMergeTime{
+		{TeamName: "ttm-backend", Week: first, MergedCount: 3, MedianSeconds: 10800, P90Seconds: 16560},
+		{TeamName: "ttm-backend", Week: second, MergedCount: 1, MedianSeconds: 259200, P90Seconds: 259200},
+		{TeamName: "ttm-frontend", Week: first, MergedCount: 1, MedianSeconds: 7200, P90Seconds: 7200},
+	}, stat.Teams)
+	require.Equal(t, []api.ReviewerMergeTime{
+		{UserId: "ttm-r1", Week: first, MergedCount: 2, MedianSeconds: 7200, P90Seconds: 10080},
+		{UserId: "ttm-r1", Week: second, MergedCount: 1, MedianSeconds: 259200, P90Seconds: 259200},
+		{UserId: "ttm-r2", Week: first, MergedCount: 2, MedianSeconds: 14400, P90Seconds: 17280},
+		{UserId: "ttm-r3", Week: first, MergedCount: 1, MedianSeconds: 7200, P90Seconds: 7200},
+	}, stat.Reviewers)
+
+	// Only pull requests of the team authors merged in the window.
+	team := "ttm-frontend"
+	stat, err = storage.GetTimeToMerge(ctx, api.GetStatsTimeToMergeParams{
+		From:     at("2025-03-01"),
+		To:       at("2025-03-10"),
+		TeamName: &team,
+	})
+	require.NoError(t, err)
+	require.Len(t, stat.Teams, 1)
+	require.Equal(t, []api.ReviewerMergeTime{
+		{UserId: "ttm-r3", Week: first, MergedCount: 1, MedianSeconds: 7200, P90Seconds: 7200},
+	}, stat.Reviewers)
+
+	_, err = storage.GetTimeToMerge(ctx, api.GetStatsTimeToMergeParams{From: at("2025-03-10"), To: at("2025-03-01")})
+	require.ErrorIs(t, err, postgres.ErrInvalidListParams)
+}
+
+func TestFairness(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('fair-1', 'alice', 'fair-a', true),
+			('fair-2', 'bob', 'fair-a', true),
+			('fair-3', 'charlie', 'fair-a', true),
+			('fair-4', 'dave', 'fair-a', false),
+			('fair-author', 'erin', 'fair-b', true)
+		`)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, createdAt) VALUES
+			('fair-p1', 'One', 'fair-author', '{fair-1,fair-2}', '2025-04-01'),
+			('fair-p2', 'Two', 'fair-author', '{fair-1,fair-4}', '2025-04-02'),
+			('fair-p3', 'Three', 'fair-author', '{fair-1}', '2025-04-03'),
+			('fair-p4', 'Four', 'fair-author', '{fair-3}', '2025-06-01')
+		`)
+	require.NoError(t, err)
+
+	at := func(s string) *time.Time {
+		day, err := time.Parse(time.DateOnly, s)
+		require.NoError(t, err)
+		return &day
+	}
+
+	// Inactive members are left out, members without assignments are counted.
+	team := "fair-a"
+	stat, err := storage.GetFairness(ctx, api.GetStatsFairnessParams{
+		From:     at("2025-04-01"),
+		To:       at("2025-05-01"),
+		TeamName: &team,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []api.TeamFairness{{
+		TeamName:        "fair-a",
+		MemberCount:     3,
+		AssignmentCount: 4,
+		Mean:            1.333,
+		Min:             0,
+		Max:             3,
+		Stddev:          1.247,
+		Gini:            0.5,
+		Members: []api.MemberLoad{
+			{UserId: "fair-1", AssignmentCount: 3},
+			{UserId: "fair-2", AssignmentCount: 1},
+			{UserId: "fair-3", AssignmentCount: 0},
+		},
+		OverMean:  []string{"fair-1"},
+		UnderMean: []string{"fair-2", "fair-3"},
+	}}, stat.Teams)
+
+	stat, err = storage.GetFairness(ctx, api.GetStatsFairnessParams{From: at("2025-04-01"), To: at("2025-05-01")})
+	require.NoError(t, err)
+	require.Contains(t, stat.Teams, api.TeamFairness{
+		TeamName:    "fair-b",
+		MemberCount: 1,
+		Members:     []api.MemberLoad{{UserId: "fair-author"}},
+		OverMean:    []string{},
+		UnderMean:   []string{},
+	})
+
+	_, err = storage.GetFairness(ctx, api.GetStatsFairnessParams{From: at("2025-05-01"), To: at("2025-04-01")})
+	require.ErrorIs(t, err, postgres.ErrInvalidListParams)
+}
+
+func TestStatsSnapshot(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('snap-a1', 'alice', 'snap-backend', true),
+			('snap-r1', 'bob', 'snap-backend', true),
+			('snap-r2', 'charlie', 'snap-frontend', true)
+		`)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt, mergedAt) VALUES
+			('snap-1', 'One', 'snap-a1', '{snap-r1,snap-r2}', 'OPEN', '2025-05-05 10:00', NULL),
+			('snap-2', 'Two', 'snap-a1', '{snap-r1}', 'MERGED', '2025-05-01 10:00', '2025-05-05 12:00'),
+			('snap-3', 'Three', 'snap-a1', '{snap-r1}', 'OPEN', '2025-05-06 10:00', NULL)
+		`)
+	require.NoError(t, err)
+
+	day := time.Date(2025, 5, 5, 15, 0, 0, 0, time.UTC)
+	n, err := storage.SaveStatsSnapshot(ctx, day)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	type row struct {
+		subjectType, subjectId, teamName string
+		prs, open, merged, reassignments int
+	}
+	rows, err := tx.Query(ctx, `SELECT subject_type, subject_id, team_name,
+		pull_request_count, open_count, merged_count, reassignment_count
+	FROM stats_snapshots
+	WHERE snapshot_date = '2025-05-05'
+	ORDER BY subject_type, subject_id`)
+	require.NoError(t, err)
+	snapshot, err := pgx.CollectRows(rows, func(r pgx.CollectableRow) (row, error) {
+		var s row
+		return s, r.Scan(&s.subjectType, &s.subjectId, &s.teamName, &s.prs, &s.open, &s.merged, &s.reassignments)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []row{
+		{"user", "snap-r1", "snap-backend", 2, 1, 1, 0},
+		{"user", "snap-r2", "snap-frontend", 1, 1, 0, 0},
+		{"team", "snap-backend", "snap-backend", 2, 1, 1, 0},
+	}, snapshot)
+
+	// The snapshot of a day is written once, new subjects are not appended.
+	_, err = tx.Exec(ctx, `DELETE FROM pull_requests WHERE pull_request_id = 'snap-1'`)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt) VALUES
+			('snap-4', 'Four', 'snap-r2', '{snap-a1}', 'OPEN', '2025-05-05 11:00')
+		`)
+	require.NoError(t, err)
+	n, err = storage.SaveStatsSnapshot(ctx, day)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	last, err := storage.LastStatsSnapshotDate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "2025-05-05", last.Format(time.DateOnly))
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"testing"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStreamQueries(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('author2', 'charlie', 'frontend', true),
+			('reviewer2', 'dave', 'frontend', true)
+		`)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.CheckEventFilter(ctx, postgres.EventFilter{UserId: "author1", TeamName: "frontend"}))
+	err = storage.CheckEventFilter(ctx, postgres.EventFilter{UserId: "missing"})
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+	err = storage.CheckEventFilter(ctx, postgres.EventFilter{TeamName: "missing"})
+	require.ErrorIs(t, err, postgres.ErrTeamNotFound)
+
+	start, err := storage.LastEventId(ctx)
+	require.NoError(t, err)
+
+	for _, pr := range []api.PostPullRequestCreateJSONBody{
+		{AuthorId: "author1", PullRequestId: "pr1", PullRequestName: "Backend PR"},
+		{AuthorId: "author2", PullRequestId: "pr2", PullRequestName: "Frontend PR"},
+	} {
+		_, err = storage.CreatePullRequest(ctx, pr, pr.AuthorId)
+		require.NoError(t, err)
+	}
+	_, err = storage.Merge(ctx, "pr1", "author1")
+	require.NoError(t, err)
+
+	all, err := storage.ListEventsAfter(ctx, start, postgres.EventFilter{}, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Equal(t, []string{"pr1", "pr2", "pr1"}, []string{all[0].PullRequestId, all[1].PullRequestId, all[2].PullRequestId})
+
+	last, err := storage.LastEventId(ctx)
+	require.NoError(t, err)
+	require.Equal(t, all[2].EventId, last)
+
+	backend, err := storage.ListEventsAfter(ctx, start, postgres.EventFilter{TeamName: "backend"}, 10)
+	require.NoError(t, err)
+	require.Len(t, backend, 2)
+	require.Equal(t, api.Created, backend[0].EventType)
+	require.Equal(t, api.Merged, backend[1].EventType)
+
+	reviewer2, err := storage.ListEventsAfter(ctx, start, postgres.EventFilter{UserId: "reviewer2"}, 10)
+	require.NoError(t, err)
+	require.Len(t, reviewer2, 1)
+	require.Equal(t, "pr2", reviewer2[0].PullRequestId)
+
+	rest, err := storage.ListEventsAfter(ctx, all[0].EventId, postgres.EventFilter{}, 1)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.Equal(t, all[1].EventId, rest[0].EventId)
+}
@@ -103,6 +103,34 @@ func TestAddTeamUpdateUser(t *testing.T) {
 	require.Equal(t, "user1", actual.Members[0].UserId)
 }
 
+func TestAddTeamKeepsRole(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active, role) VALUES
+			('user1', 'alice', 'frontend', true, 'lead')
+		`)
+	require.NoError(t, err)
+
+	_, err = storage.AddTeam(ctx, api.Team{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "user1", Username: "alice", IsActive: true},
+			{UserId: "user2", Username: "bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	actual, err := storage.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	roles := map[string]api.TeamMemberRole{}
+	for _, m := range actual.Members {
+		roles[m.UserId] = *m.Role
+	}
+	require.Equal(t, map[string]api.TeamMemberRole{"user1": api.Lead, "user2": api.Member}, roles)
+}
+
 func TestAddTeamAlreadyExists(t *testing.T) {
 	_, storage, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -158,3 +186,225 @@ func TestIsTeamExists(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, ok)
 }
+
+func TestListTeams(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'bob', 'backend', false),
+			('user3', 'charlie', 'frontend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'user1', '{}', 'OPEN'),
+			('pr2', 'PR 2', 'user2', '{}', 'MERGED'),
+			('pr3', 'PR 3', 'user3', '{}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	limit := 1
+	page, err := storage.ListTeams(ctx, api.GetTeamListParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, []api.TeamSummary{{
+		TeamName:             "backend",
+		MemberCount:          2,
+		ActiveMemberCount:    1,
+		OpenPullRequestCount: 1,
+	}}, page.Teams)
+	require.NotNil(t, page.NextCursor)
+
+	page, err = storage.ListTeams(ctx, api.GetTeamListParams{Limit: &limit, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Teams, 1)
+	require.Equal(t, "frontend", page.Teams[0].TeamName)
+	require.Nil(t, page.NextCursor)
+}
+
+func TestAddTeamWithRoles(t *testing.T) {
+	_, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	lead := api.Lead
+	_, err := storage.AddTeam(ctx, api.Team{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "user1", Username: "alice", IsActive: true, Role: &lead},
+			{UserId: "user2", Username: "bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	team, err := storage.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+
+	roles := make(map[string]api.TeamMemberRole, len(team.Members))
+	for _, m := range team.Members {
+		require.NotNil(t, m.Role)
+		roles[m.UserId] = *m.Role
+	}
+	require.Equal(t, map[string]api.TeamMemberRole{
+		"user1": api.Lead,
+		"user2": api.Member,
+	}, roles)
+
+	ok, err := storage.IsTeamLead(ctx, "user1", "backend")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.IsTeamLead(ctx, "user2", "backend")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = storage.IsTeamLead(ctx, "user1", "frontend")
+	require.ErrorIs(t, err, postgres.ErrTeamNotFound)
+	require.False(t, ok)
+}
+
+func TestIsTeamLeadInactive(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active, role) VALUES
+			('lead1', 'alice', 'backend', false, 'lead'),
+			('user1', 'bob', 'backend', true, 'member')
+		`)
+	require.NoError(t, err)
+
+	ok, err := storage.IsTeamLead(ctx, "lead1", "backend")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = storage.IsLead(ctx, "lead1")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestDeactivateTeamMembers(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('reviewer2', 'charlie', 'backend', true),
+			('reviewer3', 'dave', 'backend', true),
+			('other1', 'eve', 'frontend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'author1', '{"reviewer1","reviewer2"}', 'OPEN'),
+			('pr2', 'PR 2', 'author1', '{"reviewer1"}', 'MERGED')
+		`)
+	require.NoError(t, err)
+
+	result, err := storage.DeactivateTeamMembers(ctx, "backend", []string{"reviewer1", "other1"}, "lead1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviewer1"}, result.DeactivatedUserIds)
+	require.Len(t, result.Reassignments, 1)
+	require.Equal(t, "pr1", result.Reassignments[0].PullRequestId)
+	require.Equal(t, "reviewer1", result.Reassignments[0].OldUserId)
+	require.Equal(t, "reviewer3", *result.Reassignments[0].ReplacedBy)
+
+	var reviewers []string
+	err = tx.QueryRow(ctx,
+		"SELECT assigned_reviewers FROM pull_requests WHERE pull_request_id = 'pr1'").Scan(&reviewers)
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviewer3", "reviewer2"}, reviewers)
+
+	err = tx.QueryRow(ctx,
+		"SELECT assigned_reviewers FROM pull_requests WHERE pull_request_id = 'pr2'").Scan(&reviewers)
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviewer1"}, reviewers)
+
+	var isActive bool
+	err = tx.QueryRow(ctx, "SELECT is_active FROM users WHERE user_id = 'other1'").Scan(&isActive)
+	require.NoError(t, err)
+	require.True(t, isActive)
+}
+
+func TestDeactivateTeamMembersNoCandidate(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'author1', '{"reviewer1"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	result, err := storage.DeactivateTeamMembers(ctx, "backend", []string{"reviewer1"}, "lead1")
+	require.NoError(t, err)
+	require.Len(t, result.Reassignments, 1)
+	require.Nil(t, result.Reassignments[0].ReplacedBy)
+
+	var reviewers []string
+	err = tx.QueryRow(ctx,
+		"SELECT assigned_reviewers FROM pull_requests WHERE pull_request_id = 'pr1'").Scan(&reviewers)
+	require.NoError(t, err)
+	require.Empty(t, reviewers)
+}
+
+func TestDeactivateTeamMembersReplacesFromAuthorTeam(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('other1', 'charlie', 'frontend', true),
+			('other2', 'dave', 'frontend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'author1', '{"other1"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	result, err := storage.DeactivateTeamMembers(ctx, "frontend", []string{"other1"}, "lead1")
+	require.NoError(t, err)
+	require.Len(t, result.Reassignments, 1)
+	require.Equal(t, "reviewer1", *result.Reassignments[0].ReplacedBy)
+}
+
+func TestDeactivateNonExistentTeamMembers(t *testing.T) {
+	_, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	result, err := storage.DeactivateTeamMembers(ctx, "NONEXISTENT", []string{"user1"}, "lead1")
+	require.Nil(t, result)
+	require.ErrorIs(t, err, postgres.ErrTeamNotFound)
+}
+
+func TestSetTeamMembers(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'bob', 'backend', true),
+			('user3', 'charlie', 'frontend', true)
+		`)
+	require.NoError(t, err)
+
+	err = storage.SetTeamMembers(ctx, "backend", []string{"user3"}, []string{"user2"}, "unassigned")
+	require.NoError(t, err)
+
+	team, err := storage.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	memberIDs := make([]string, len(team.Members))
+	for i, m := range team.Members {
+		memberIDs[i] = m.UserId
+	}
+	require.ElementsMatch(t, []string{"user1", "user3"}, memberIDs)
+
+	teamName, err := storage.GetTeamNameByUserId(ctx, tx, "user2")
+	require.NoError(t, err)
+	require.Equal(t, "unassigned", teamName)
+
+	err = storage.SetTeamMembers(ctx, "backend", []string{"NONEXISTENT"}, nil, "unassigned")
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+}
@@ -69,10 +69,13 @@ func TestGetReview(t *testing.T) {
 		`)
 	require.NoError(t, err)
 
-	prs, err := storage.GetReview(ctx, "reviewer1")
+	all := api.GetUsersGetReviewParamsStatusALL
+	review, err := storage.GetReview(ctx, api.GetUsersGetReviewParams{UserId: "reviewer1", Status: &all})
 	require.NoError(t, err)
 
+	prs := review.PullRequests
 	require.Len(t, prs, 2)
+	require.Equal(t, 2, review.Total)
 
 	prIDs := make([]string, len(prs))
 	for i, pr := range prs {
@@ -83,7 +86,7 @@ func TestGetReview(t *testing.T) {
 	var foundPR *api.PullRequestShort
 	for _, pr := range prs {
 		if pr.PullRequestId == "pr1" {
-			foundPR = pr
+			foundPR = &pr
 			break
 		}
 	}
@@ -93,6 +96,57 @@ func TestGetReview(t *testing.T) {
 	require.Equal(t, api.PullRequestShortStatusOPEN, foundPR.Status)
 }
 
+func TestGetReviewFilterAndPagination(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'author1', 'backend', true),
+			('reviewer1', 'reviewer1', 'backend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status, createdAt) VALUES
+			('pr1', 'PR 1', 'author1', '{"reviewer1"}', 'OPEN', '2025-01-01 10:00:00'),
+			('pr2', 'PR 2', 'author1', '{"reviewer1"}', 'MERGED', '2025-01-02 10:00:00'),
+			('pr3', 'PR 3', 'author1', '{"reviewer1"}', 'OPEN', '2025-01-03 10:00:00'),
+			('pr4', 'PR 4', 'author1', '{"reviewer1"}', 'OPEN', '2025-01-04 10:00:00')
+		`)
+	require.NoError(t, err)
+
+	ids := func(review *api.ReviewList) []string {
+		result := make([]string, len(review.PullRequests))
+		for i, pr := range review.PullRequests {
+			result[i] = pr.PullRequestId
+		}
+		return result
+	}
+
+	limit := 2
+	review, err := storage.GetReview(ctx, api.GetUsersGetReviewParams{UserId: "reviewer1", Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr4", "pr3"}, ids(review))
+	require.Equal(t, 3, review.Total)
+	require.NotNil(t, review.NextCursor)
+
+	review, err = storage.GetReview(ctx, api.GetUsersGetReviewParams{
+		UserId: "reviewer1",
+		Limit:  &limit,
+		Cursor: review.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr1"}, ids(review))
+	require.Equal(t, 3, review.Total)
+	require.Nil(t, review.NextCursor)
+
+	merged, order := api.GetUsersGetReviewParamsStatusMERGED, api.Asc
+	review, err = storage.GetReview(ctx, api.GetUsersGetReviewParams{UserId: "reviewer1", Status: &merged, Order: &order})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr2"}, ids(review))
+
+	cursor := "not a cursor"
+	_, err = storage.GetReview(ctx, api.GetUsersGetReviewParams{UserId: "reviewer1", Cursor: &cursor})
+	require.ErrorIs(t, err, postgres.ErrInvalidCursor)
+}
+
 func TestGetTeamByUserId(t *testing.T) {
 	tx, storage, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -120,3 +174,172 @@ func TestGetTeamByUserIdNonExistUser(t *testing.T) {
 	_, err := storage.GetTeamNameByUserId(ctx, tx, "FFFFFF")
 	require.ErrorIs(t, err, postgres.ErrUserNotFound)
 }
+
+func TestGetUser(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'author1', 'backend', true),
+			('reviewer1', 'reviewer1', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, `
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'author1', '{"reviewer1"}', 'OPEN'),
+			('pr2', 'PR 2', 'author1', '{"reviewer1"}', 'MERGED'),
+			('pr3', 'PR 3', 'author1', '{"reviewer1"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	user, err := storage.GetUser(ctx, "reviewer1")
+	require.NoError(t, err)
+	require.Equal(t, api.UserDetails{
+		UserId:          "reviewer1",
+		Username:        "reviewer1",
+		TeamName:        "backend",
+		IsActive:        true,
+		OpenReviewCount: 2,
+	}, *user)
+}
+
+func TestGetUserNonExistUser(t *testing.T) {
+	_, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	user, err := storage.GetUser(ctx, "NONEXISTUSERID")
+	require.Nil(t, user)
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+}
+
+func TestListUsers(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'albert', 'backend', false),
+			('user3', 'bob', 'backend', true),
+			('user4', 'alex', 'frontend', true)
+		`)
+	require.NoError(t, err)
+
+	team, prefix := "backend", "al"
+	users, err := storage.ListUsers(ctx, api.GetUsersListParams{
+		TeamName:       &team,
+		UsernamePrefix: &prefix,
+	})
+	require.NoError(t, err)
+	require.Len(t, users.Users, 2)
+	require.Equal(t, "user1", users.Users[0].UserId)
+	require.Equal(t, "user2", users.Users[1].UserId)
+	require.Nil(t, users.NextCursor)
+
+	active := true
+	users, err = storage.ListUsers(ctx, api.GetUsersListParams{IsActive: &active})
+	require.NoError(t, err)
+	require.Len(t, users.Users, 3)
+	for _, u := range users.Users {
+		require.True(t, u.IsActive)
+	}
+}
+
+func TestListUsersPagination(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'bob', 'backend', true),
+			('user3', 'charlie', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	limit := 2
+	page, err := storage.ListUsers(ctx, api.GetUsersListParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 2)
+	require.NotNil(t, page.NextCursor)
+
+	page, err = storage.ListUsers(ctx, api.GetUsersListParams{Limit: &limit, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	require.Equal(t, "user3", page.Users[0].UserId)
+	require.Nil(t, page.NextCursor)
+
+	cursor := "not a cursor"
+	_, err = storage.ListUsers(ctx, api.GetUsersListParams{Cursor: &cursor})
+	require.ErrorIs(t, err, postgres.ErrInvalidCursor)
+}
+
+func TestFindUsers(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('user1', 'alice', 'backend', true),
+			('user2', 'bob', 'backend', false),
+			('user3', 'charlie', 'frontend', true)
+		`)
+	require.NoError(t, err)
+
+	active := true
+	users, total, err := storage.FindUsers(ctx, postgres.UserFilter{IsActive: &active}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, users, 1)
+	require.Equal(t, "user3", users[0].UserId)
+
+	username := "bob"
+	users, total, err = storage.FindUsers(ctx, postgres.UserFilter{Username: &username}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "user2", users[0].UserId)
+}
+
+func TestCreateUser(t *testing.T) {
+	_, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	u := api.User{UserId: "user1", Username: "alice", TeamName: "backend", IsActive: true}
+	created, err := storage.CreateUser(ctx, u)
+	require.NoError(t, err)
+	require.Equal(t, u, *created)
+
+	_, err = storage.CreateUser(ctx, u)
+	require.ErrorIs(t, err, postgres.ErrUserExists)
+}
+
+func TestUpdateUserDeactivation(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true),
+			('reviewer2', 'charlie', 'backend', true);
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, assigned_reviewers, status) VALUES
+			('pr1', 'PR 1', 'author1', '{"reviewer1"}', 'OPEN')
+		`)
+	require.NoError(t, err)
+
+	u := api.User{UserId: "reviewer1", Username: "bobby", TeamName: "backend", IsActive: false}
+	updated, err := storage.UpdateUser(ctx, u)
+	require.NoError(t, err)
+	require.Equal(t, u, *updated)
+
+	var reviewers []string
+	err = tx.QueryRow(ctx,
+		"SELECT assigned_reviewers FROM pull_requests WHERE pull_request_id = 'pr1'").Scan(&reviewers)
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviewer2"}, reviewers)
+
+	_, err = storage.UpdateUser(ctx, api.User{UserId: "NONEXISTUSERID"})
+	require.ErrorIs(t, err, postgres.ErrUserNotFound)
+}
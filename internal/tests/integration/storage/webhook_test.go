@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"avito-trainee-task/internal/api"
+	"avito-trainee-task/internal/storage/postgres"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookOutbox(t *testing.T) {
+	tx, storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	_, err := tx.Exec(ctx, `
+			INSERT INTO users (user_id, username, team_name, is_active) VALUES
+			('author1', 'alice', 'backend', true),
+			('reviewer1', 'bob', 'backend', true)
+		`)
+	require.NoError(t, err)
+
+	sub, err := storage.CreateWebhookSubscription(ctx, api.PostWebhooksSubscribeJSONBody{
+		Url:        "https://hooks.example.com/reviews",
+		Secret:     "0123456789abcdef",
+		EventTypes: &[]api.WebhookEventType{api.PrCreated},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []api.WebhookEventType{api.PrCreated}, sub.EventTypes)
+
+	_, err = storage.CreatePullRequest(ctx, api.PostPullRequestCreateJSONBody{
+		AuthorId:        "author1",
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+	}, "author1")
+	require.NoError(t, err)
+	_, err = storage.Merge(ctx, "pr1", "author1")
+	require.NoError(t, err)
+
+	var outboxEvents int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM outbox_events").Scan(&outboxEvents)
+	require.NoError(t, err)
+	require.Equal(t, 2, outboxEvents)
+
+	pending, err := storage.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, sub.Url, pending[0].URL)
+	require.Equal(t, "0123456789abcdef", pending[0].Secret)
+	require.Equal(t, api.PrCreated, pending[0].Payload.EventType)
+	require.Equal(t, "pr1", pending[0].Payload.Data.PullRequestId)
+	require.Equal(t, []string{"reviewer1"}, pending[0].Payload.Data.Reviewers)
+
+	again, err := storage.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	deliveryId := pending[0].DeliveryId
+	statusCode := 500
+	retryIn := time.Minute
+	require.NoError(t, storage.MarkWebhookFailed(ctx, deliveryId, &statusCode, "unexpected status", &retryIn))
+	require.NoError(t, storage.MarkWebhookFailed(ctx, deliveryId, nil, "connection refused", nil))
+
+	dead := api.Dead
+	list, err := storage.ListWebhookDeliveries(ctx, api.GetWebhooksDeliveriesParams{Status: &dead})
+	require.NoError(t, err)
+	require.Len(t, list.Deliveries, 1)
+	require.Equal(t, 2, list.Deliveries[0].Attempts)
+	require.Equal(t, "connection refused", *list.Deliveries[0].LastError)
+
+	delivery, err := storage.RedeliverWebhook(ctx, deliveryId)
+	require.NoError(t, err)
+	require.Equal(t, api.Pending, delivery.Status)
+	require.Zero(t, delivery.Attempts)
+
+	_, err = storage.RedeliverWebhook(ctx, -1)
+	require.ErrorIs(t, err, postgres.ErrDeliveryNotFound)
+
+	_, err = storage.DeleteWebhookSubscription(ctx, sub.SubscriptionId)
+	require.NoError(t, err)
+	_, err = storage.DeleteWebhookSubscription(ctx, sub.SubscriptionId)
+	require.ErrorIs(t, err, postgres.ErrSubscriptionNotFound)
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"avito-trainee-task/internal/storage/postgres"
+	"avito-trainee-task/internal/worker"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature-256"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// ErrForbiddenAddress is returned for deliveries to loopback, link-local
+// and private addresses unless Config.AllowPrivateTargets is set.
+var ErrForbiddenAddress = errors.New("webhook target address is not allowed")
+
+type Store interface {
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]postgres.PendingDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, deliveryId int64, statusCode int) error
+	MarkWebhookFailed(
+		ctx context.Context,
+		deliveryId int64,
+		statusCode *int,
+		lastError string,
+		retryIn *time.Duration,
+	) error
+}
+
+type Config struct {
+	worker.Config
+
+	// AllowPrivateTargets permits deliveries to loopback, link-local and
+	// private addresses, e.g. for subscribers in the same network.
+	AllowPrivateTargets bool
+}
+
+// Dispatcher delivers events from the outbox to subscribers. Deliveries are
+// at least once: a delivery interrupted by shutdown is retried after its lease.
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	cfg    Config
+}
+
+func NewDispatcher(store Store, cfg Config) *Dispatcher {
+	// Targets are checked when connecting rather than when subscribing,
+	// so that a host name resolving to another address later is rejected
+	// too. Proxies are not used as they would hide the target address.
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateTargets {
+		dialer.Control = checkAddress
+	}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: cfg.Timeout,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		cfg:    cfg,
+	}
+}
+
+// Run dispatches due deliveries every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	worker.Run(ctx, d.cfg.Interval, "failed to dispatch webhooks", func(ctx context.Context) error {
+		_, err := d.DispatchOnce(ctx)
+		return err
+	})
+}
+
+// DispatchOnce sends one batch of due deliveries and returns its size.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimWebhookDeliveries(ctx, d.cfg.BatchSize, 2*d.cfg.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.deliver(ctx, delivery); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "failed to record webhook delivery",
+					"delivery_id", delivery.DeliveryId,
+					"error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery postgres.PendingDelivery) error {
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		return fmt.Errorf("webhook.deliver failed to marshal payload: %w", err)
+	}
+
+	statusCode, err := d.send(ctx, delivery, body)
+	if err == nil {
+		return d.store.MarkWebhookDelivered(ctx, delivery.DeliveryId, statusCode)
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+
+	retryIn := d.cfg.RetryIn(delivery.Attempts+1, true)
+	return d.store.MarkWebhookFailed(ctx, delivery.DeliveryId, code, worker.ErrorMessage(err), retryIn)
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery postgres.PendingDelivery, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.Payload.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.DeliveryId, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// checkAddress rejects connections to addresses that are not public
+// unicast, such as loopback, link-local (including cloud metadata) and
+// private ranges.
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, address)
+	}
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, addr)
+	}
+	return nil
+}
+
+// Sign returns the HMAC-SHA256 signature of the body in the
+// sha256=<hex> form sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
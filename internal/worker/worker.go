@@ -0,0 +1,82 @@
+// Package worker holds the polling loop and the retry policy shared by the
+// background workers processing queued deliveries.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxErrorLength limits the error message stored with a failed attempt.
+const MaxErrorLength = 512
+
+type Config struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+}
+
+// Run calls once right away and then every interval until ctx is done.
+// Errors returned before ctx is done are logged with msg.
+func Run(ctx context.Context, interval time.Duration, msg string, once func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := once(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, msg, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RetryIn returns the delay before the next attempt after the given one
+// failed, or nil when the error is not retryable or no attempts are left.
+func (c Config) RetryIn(attempt int, retryable bool) *time.Duration {
+	if !retryable || attempt >= c.MaxAttempts {
+		return nil
+	}
+	backoff := Backoff(attempt, c.MinBackoff, c.MaxBackoff)
+	return &backoff
+}
+
+// Backoff doubles the delay after every failed attempt up to maxDelay.
+func Backoff(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 30 {
+		return maxDelay
+	}
+	delay := minDelay << (attempt - 1)
+	if delay <= 0 || delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// ErrorMessage returns the message of err cut to MaxErrorLength.
+func ErrorMessage(err error) string {
+	return Truncate(err.Error(), MaxErrorLength)
+}
+
+// Truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,4 @@
+CREATE TYPE user_role AS ENUM ('member', 'lead');
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role user_role NOT NULL DEFAULT 'member';
@@ -0,0 +1,40 @@
+CREATE TYPE assignment_event_type AS ENUM (
+    'created',
+    'reassigned',
+    'declined',
+    'deactivated_replaced',
+    'merged'
+);
+
+CREATE TABLE IF NOT EXISTS assignment_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL,
+    event_type assignment_event_type NOT NULL,
+    actor_id TEXT,
+    old_user_id TEXT,
+    new_user_id TEXT,
+    reviewers TEXT[] NOT NULL DEFAULT '{}',
+    reason TEXT,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_pull_request
+        FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pull_request
+    ON assignment_events (pull_request_id, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_reviewers
+    ON assignment_events USING GIN (reviewers);
+
+CREATE OR REPLACE FUNCTION forbid_assignment_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'assignment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER assignment_events_append_only
+    BEFORE UPDATE OR DELETE ON assignment_events
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION forbid_assignment_event_change();
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    subscription_id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TYPE delivery_status AS ENUM ('pending', 'delivered', 'dead');
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    subscription_id BIGINT NOT NULL,
+    status delivery_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_status_code INTEGER,
+    last_error TEXT,
+    deliveredAt TIMESTAMP,
+    CONSTRAINT fk_event
+        FOREIGN KEY (event_id) REFERENCES outbox_events(event_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_subscription
+        FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(subscription_id)
+        ON DELETE CASCADE,
+    CONSTRAINT uq_delivery UNIQUE (event_id, subscription_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending
+    ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
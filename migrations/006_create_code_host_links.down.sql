@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS external_pull_requests;
+
+DROP TABLE IF EXISTS code_host_accounts;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS code_host_accounts (
+    provider TEXT NOT NULL,
+    login TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (provider, login),
+    CONSTRAINT fk_user
+        FOREIGN KEY (user_id) REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS external_pull_requests (
+    provider TEXT NOT NULL,
+    repository TEXT NOT NULL,
+    number INTEGER NOT NULL,
+    pull_request_id TEXT NOT NULL UNIQUE,
+    PRIMARY KEY (provider, repository, number),
+    CONSTRAINT fk_pull_request
+        FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_external_pull_requests_pending_sync;
+
+ALTER TABLE external_pull_requests
+    DROP COLUMN IF EXISTS syncedAt,
+    DROP COLUMN IF EXISTS synced_reviewers,
+    DROP COLUMN IF EXISTS last_sync_error,
+    DROP COLUMN IF EXISTS next_sync_at,
+    DROP COLUMN IF EXISTS sync_attempts,
+    DROP COLUMN IF EXISTS sync_version,
+    DROP COLUMN IF EXISTS sync_status;
+
+DROP TYPE IF EXISTS reviewer_sync_status;
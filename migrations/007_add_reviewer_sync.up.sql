@@ -0,0 +1,14 @@
+CREATE TYPE reviewer_sync_status AS ENUM ('pending', 'synced', 'failed');
+
+ALTER TABLE external_pull_requests
+    ADD COLUMN sync_status reviewer_sync_status NOT NULL DEFAULT 'pending',
+    ADD COLUMN sync_version BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sync_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_sync_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN last_sync_error TEXT,
+    ADD COLUMN synced_reviewers TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN syncedAt TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_external_pull_requests_pending_sync
+    ON external_pull_requests (next_sync_at)
+    WHERE sync_status = 'pending';
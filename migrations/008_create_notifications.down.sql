@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notifications;
+
+DROP TYPE IF EXISTS notification_transport;
+
+DROP TABLE IF EXISTS notification_contacts;
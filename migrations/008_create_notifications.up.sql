@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS notification_contacts (
+    user_id TEXT PRIMARY KEY,
+    chat_channel TEXT,
+    chat_handle TEXT,
+    updatedAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user
+        FOREIGN KEY (user_id) REFERENCES users(user_id)
+        ON DELETE CASCADE
+);
+
+CREATE TYPE notification_transport AS ENUM ('chat');
+
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    user_id TEXT NOT NULL,
+    transport notification_transport NOT NULL,
+    status delivery_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    sentAt TIMESTAMP,
+    CONSTRAINT fk_event
+        FOREIGN KEY (event_id) REFERENCES assignment_events(event_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_user
+        FOREIGN KEY (user_id) REFERENCES users(user_id)
+        ON DELETE CASCADE,
+    CONSTRAINT uq_notification UNIQUE (event_id, user_id, transport)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_pending
+    ON notifications (transport, next_attempt_at)
+    WHERE status = 'pending';
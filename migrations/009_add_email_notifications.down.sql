@@ -0,0 +1,9 @@
+DELETE FROM notifications WHERE transport = 'email';
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE notification_contacts
+    DROP COLUMN IF EXISTS last_reminded_at,
+    DROP COLUMN IF EXISTS email_opt_out,
+    DROP COLUMN IF EXISTS email;
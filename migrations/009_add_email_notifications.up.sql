@@ -0,0 +1,9 @@
+ALTER TYPE notification_transport ADD VALUE IF NOT EXISTS 'email';
+
+ALTER TABLE notification_contacts
+    ADD COLUMN email TEXT,
+    ADD COLUMN email_opt_out TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN last_reminded_at TIMESTAMP;
+
+ALTER TABLE notifications
+    ADD COLUMN kind TEXT NOT NULL DEFAULT 'assigned';
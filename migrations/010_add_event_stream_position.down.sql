@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_assignment_events_txid;
+
+ALTER TABLE assignment_events
+    DROP COLUMN IF EXISTS txid;
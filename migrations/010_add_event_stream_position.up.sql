@@ -0,0 +1,8 @@
+-- Event ids are taken from a sequence before commit, so they may become
+-- visible out of order. Streams order events by the writing transaction
+-- and read only transactions below the snapshot horizon.
+ALTER TABLE assignment_events
+    ADD COLUMN txid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_txid
+    ON assignment_events (txid, event_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stats_snapshots;
+
+DROP TYPE IF EXISTS snapshot_subject;
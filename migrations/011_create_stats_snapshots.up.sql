@@ -0,0 +1,18 @@
+CREATE TYPE snapshot_subject AS ENUM ('user', 'team');
+
+-- Daily aggregates of pull requests created or merged during the day. Rows
+-- keep subjects by name without foreign keys and the team of a user at
+-- the time of the snapshot, so they outlive deleted pull requests and
+-- reorganised teams.
+CREATE TABLE IF NOT EXISTS stats_snapshots (
+    snapshot_date DATE NOT NULL,
+    subject_type snapshot_subject NOT NULL,
+    subject_id TEXT NOT NULL,
+    team_name TEXT,
+    pull_request_count INTEGER NOT NULL,
+    open_count INTEGER NOT NULL,
+    merged_count INTEGER NOT NULL,
+    reassignment_count INTEGER NOT NULL,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (snapshot_date, subject_type, subject_id)
+);
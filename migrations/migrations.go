@@ -0,0 +1,8 @@
+// Package migrations embeds the schema migrations so that the server knows
+// the version it was built against.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,90 @@
+// Package client is the Go SDK of the reviewer assignment HTTP API.
+//
+// It wraps the client generated from docs/openapi.yml into the openapi
+// subpackage with typed errors, retries of idempotent calls and the
+// actor of a call taken from the context.
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// ActorHeader carries the user_id of the caller.
+const ActorHeader = "X-Actor-Id"
+
+// Client calls the HTTP API. It is safe for concurrent use.
+type Client struct {
+	api *openapi.ClientWithResponses
+}
+
+type config struct {
+	doer  openapi.HttpRequestDoer
+	actor string
+	retry Retry
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+// WithHTTPClient sets the client making the requests, http.DefaultClient
+// by default.
+func WithHTTPClient(doer openapi.HttpRequestDoer) Option {
+	return func(c *config) { c.doer = doer }
+}
+
+// WithActor sets the user_id sent as X-Actor-Id when the context of
+// the call has none.
+func WithActor(userId string) Option {
+	return func(c *config) { c.actor = userId }
+}
+
+// WithRetry replaces DefaultRetry. A zero Retry disables retries.
+func WithRetry(retry Retry) Option {
+	return func(c *config) { c.retry = retry }
+}
+
+// New creates a client of the API served at server, e.g.
+// http://localhost:8080.
+func New(server string, opts ...Option) (*Client, error) {
+	cfg := config{
+		doer:  http.DefaultClient,
+		retry: DefaultRetry,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	api, err := openapi.NewClientWithResponses(
+		strings.TrimSuffix(server, "/"),
+		openapi.WithHTTPClient(&retryDoer{next: cfg.doer, retry: cfg.retry}),
+		openapi.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			if actor := actorFromContext(ctx, cfg.actor); actor != "" {
+				req.Header.Set(ActorHeader, actor)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{api: api}, nil
+}
+
+type actorKey struct{}
+
+// WithActorContext returns a context sending userId as X-Actor-Id
+// with the calls made with it.
+func WithActorContext(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, actorKey{}, userId)
+}
+
+func actorFromContext(ctx context.Context, fallback string) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok {
+		return actor
+	}
+	return fallback
+}
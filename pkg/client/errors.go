@@ -0,0 +1,87 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// Errors matching the codes of ErrorResponse, use errors.Is to check
+// the error of a call.
+var (
+	ErrBadRequest        = errors.New("bad request")
+	ErrForbidden         = errors.New("forbidden")
+	ErrNotFound          = errors.New("not found")
+	ErrTeamExists        = errors.New("team already exists")
+	ErrPullRequestExists = errors.New("pull request already exists")
+	ErrPullRequestMerged = errors.New("pull request is merged")
+	ErrNotAssigned       = errors.New("reviewer is not assigned")
+	ErrNoCandidate       = errors.New("no replacement candidate")
+)
+
+var codeErrors = map[openapi.ErrorResponseErrorCode]error{
+	openapi.BADREQUEST:  ErrBadRequest,
+	openapi.FORBIDDEN:   ErrForbidden,
+	openapi.NOTFOUND:    ErrNotFound,
+	openapi.TEAMEXISTS:  ErrTeamExists,
+	openapi.PREXISTS:    ErrPullRequestExists,
+	openapi.PRMERGED:    ErrPullRequestMerged,
+	openapi.NOTASSIGNED: ErrNotAssigned,
+	openapi.NOCANDIDATE: ErrNoCandidate,
+}
+
+// statusErrors covers responses without an error code.
+var statusErrors = map[int]error{
+	http.StatusBadRequest: ErrBadRequest,
+	http.StatusForbidden:  ErrForbidden,
+	http.StatusNotFound:   ErrNotFound,
+}
+
+// APIError is an unexpected response of the API.
+type APIError struct {
+	StatusCode int
+	// Code is empty when the response has no ErrorResponse body.
+	Code    string
+	Message string
+
+	err error
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Code != "":
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	case e.Message != "":
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+func apiError(rsp *http.Response, body []byte) error {
+	e := &APIError{StatusCode: rsp.StatusCode}
+
+	var errResp openapi.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Code != "" {
+		e.Code = string(errResp.Error.Code)
+		e.Message = errResp.Error.Message
+		e.err = codeErrors[errResp.Error.Code]
+		return e
+	}
+
+	// Errors of the framework have a message only.
+	var echoErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &echoErr); err == nil {
+		e.Message = echoErr.Message
+	}
+	e.err = statusErrors[rsp.StatusCode]
+	return e
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// SetCodeHostAccount links the code host login to the user.
+func (c *Client) SetCodeHostAccount(ctx context.Context, account CodeHostAccount) (*CodeHostAccount, error) {
+	rsp, err := c.api.PostIntegrationsAccountsSetWithResponse(idempotent(ctx), account)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Account, nil
+}
+
+func (c *Client) DeleteCodeHostAccount(ctx context.Context, provider CodeHostProvider, login string) (*CodeHostAccount, error) {
+	rsp, err := c.api.PostIntegrationsAccountsDeleteWithResponse(ctx, openapi.PostIntegrationsAccountsDeleteJSONRequestBody{
+		Provider: provider,
+		Login:    login,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Account, nil
+}
+
+// ListCodeHostAccounts returns accounts of all providers when provider is nil.
+func (c *Client) ListCodeHostAccounts(ctx context.Context, provider *CodeHostProvider) ([]CodeHostAccount, error) {
+	rsp, err := c.api.GetIntegrationsAccountsListWithResponse(ctx, &openapi.GetIntegrationsAccountsListParams{
+		Provider: provider,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200.Accounts, nil
+}
+
+// RetryReviewerSync schedules the reviewer sync of the pull request
+// to the code host again.
+func (c *Client) RetryReviewerSync(ctx context.Context, pullRequestId string) (*ReviewerSync, error) {
+	rsp, err := c.api.PostIntegrationsSyncRetryWithResponse(idempotent(ctx), openapi.PostIntegrationsSyncRetryJSONRequestBody{
+		PullRequestId: pullRequestId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Sync, nil
+}
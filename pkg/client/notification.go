@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// SetNotificationContact replaces the chat and email contacts of the user.
+func (c *Client) SetNotificationContact(ctx context.Context, contact NotificationContact) (*NotificationContact, error) {
+	rsp, err := c.api.PostNotificationsContactsSetWithResponse(idempotent(ctx), contact)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Contact, nil
+}
+
+func (c *Client) DeleteNotificationContact(ctx context.Context, userId string) (*NotificationContact, error) {
+	rsp, err := c.api.PostNotificationsContactsDeleteWithResponse(ctx, openapi.PostNotificationsContactsDeleteJSONRequestBody{
+		UserId: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Contact, nil
+}
+
+func (c *Client) ListNotificationContacts(ctx context.Context) ([]NotificationContact, error) {
+	rsp, err := c.api.GetNotificationsContactsListWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200.Contacts, nil
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// CreatePullRequest creates a pull request and assigns up to two
+// reviewers from the team of the author.
+func (c *Client) CreatePullRequest(ctx context.Context, req CreatePullRequestRequest) (*PullRequest, error) {
+	rsp, err := c.api.PostPullRequestCreateWithResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON201 == nil || rsp.JSON201.Pr == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON201.Pr, nil
+}
+
+// MergePullRequest marks the pull request merged, merging it again
+// returns its current state.
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestId string) (*PullRequest, error) {
+	rsp, err := c.api.PostPullRequestMergeWithResponse(idempotent(ctx), openapi.PostPullRequestMergeJSONRequestBody{
+		PullRequestId: pullRequestId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil || rsp.JSON200.Pr == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200.Pr, nil
+}
+
+// ReassignPullRequest replaces the reviewer and returns the pull request
+// with the user_id of the new reviewer.
+func (c *Client) ReassignPullRequest(ctx context.Context, req ReassignPullRequestRequest) (*PullRequest, string, error) {
+	rsp, err := c.api.PostPullRequestReassignWithResponse(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	if rsp.JSON200 == nil {
+		return nil, "", apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Pr, rsp.JSON200.ReplacedBy, nil
+}
+
+// GetPullRequest returns the pull request and the state of the reviewer
+// sync when it is linked to a code host.
+func (c *Client) GetPullRequest(ctx context.Context, pullRequestId string) (*PullRequest, *ReviewerSync, error) {
+	details, err := c.GetPullRequestDetails(ctx, pullRequestId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &details.Pr, details.Sync, nil
+}
+
+// GetPullRequestDetails returns the pull request with its reviewer sync state
+// and the first page of its assignment history.
+func (c *Client) GetPullRequestDetails(ctx context.Context, pullRequestId string) (*PullRequestDetails, error) {
+	rsp, err := c.api.GetPullRequestGetWithResponse(ctx, &openapi.GetPullRequestGetParams{PullRequestId: pullRequestId})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+func (c *Client) ListPullRequests(ctx context.Context, params ListPullRequestsParams) (*PullRequestList, error) {
+	rsp, err := c.api.GetPullRequestListWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+func (c *Client) GetPullRequestHistory(ctx context.Context, params PullRequestHistoryParams) (*AssignmentEventList, error) {
+	rsp, err := c.api.GetPullRequestHistoryWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
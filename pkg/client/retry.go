@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// Retry configures retries of idempotent calls after network errors
+// and 429, 502, 503 and 504 responses. Delays grow exponentially from
+// MinBackoff up to MaxBackoff.
+type Retry struct {
+	// MaxAttempts includes the first attempt, values below 2 disable retries.
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultRetry is used by clients created without WithRetry.
+var DefaultRetry = Retry{
+	MaxAttempts: 3,
+	MinBackoff:  200 * time.Millisecond,
+	MaxBackoff:  2 * time.Second,
+}
+
+type idempotentKey struct{}
+
+// idempotent marks a non-GET call safe to repeat.
+func idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+type retryDoer struct {
+	next  openapi.HttpRequestDoer
+	retry Retry
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !d.retryable(req) {
+		return d.next.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		rsp, err := d.next.Do(req)
+		if attempt >= d.retry.MaxAttempts || !temporary(rsp, err) || ctx.Err() != nil {
+			return rsp, err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(io.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff(attempt, d.retry.MinBackoff, d.retry.MaxBackoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (d *retryDoer) retryable(req *http.Request) bool {
+	if d.retry.MaxAttempts < 2 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	ok, _ := req.Context().Value(idempotentKey{}).(bool)
+	return ok || req.Method == http.MethodGet
+}
+
+func temporary(rsp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch rsp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func backoff(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	if attempt > 30 {
+		return maxDelay
+	}
+	delay := minDelay << (attempt - 1)
+	if delay <= 0 || delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,29 @@
+package client
+
+import "context"
+
+// GetTimeToMerge returns the median and the 90th percentile of the time from
+// creation to merge by week for teams and reviewers.
+func (c *Client) GetTimeToMerge(ctx context.Context, params TimeToMergeParams) (*TimeToMergeStat, error) {
+	rsp, err := c.api.GetStatsTimeToMergeWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetFairness returns the distribution of review assignments among active
+// members of every team.
+func (c *Client) GetFairness(ctx context.Context, params FairnessParams) (*FairnessStat, error) {
+	rsp, err := c.api.GetStatsFairnessWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
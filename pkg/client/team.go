@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+// Content types of organization chart imports.
+const (
+	ImportCSV  = "text/csv"
+	ImportYAML = "application/yaml"
+)
+
+func (c *Client) AddTeam(ctx context.Context, team Team) (*Team, error) {
+	rsp, err := c.api.PostTeamAddWithResponse(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON201 == nil || rsp.JSON201.Team == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON201.Team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	rsp, err := c.api.GetTeamGetWithResponse(ctx, &openapi.GetTeamGetParams{TeamName: teamName})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+func (c *Client) ListTeams(ctx context.Context, params ListTeamsParams) (*TeamList, error) {
+	rsp, err := c.api.GetTeamListWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+// DeactivateTeamMembers deactivates the users and reassigns their open
+// reviews. The actor must be the team lead.
+func (c *Client) DeactivateTeamMembers(ctx context.Context, teamName string, userIds ...string) (*DeactivationResult, error) {
+	rsp, err := c.api.PostTeamDeactivateMembersWithResponse(ctx, openapi.PostTeamDeactivateMembersJSONRequestBody{
+		TeamName: teamName,
+		UserIds:  userIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+// Import applies the organization chart read from body, contentType is
+// ImportCSV or ImportYAML. With DryRun only the report is returned, users
+// missing from the chart are deactivated only with DeactivateMissing.
+func (c *Client) Import(ctx context.Context, contentType string, body io.Reader, params ImportParams) (*ImportReport, error) {
+	rsp, err := c.api.PostImportWithBodyWithResponse(ctx, &params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
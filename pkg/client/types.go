@@ -0,0 +1,74 @@
+package client
+
+import "avito-trainee-task/pkg/client/openapi"
+
+// Models of the API, enum constants are declared in the openapi package.
+type (
+	AssignmentCount          = openapi.AssignmentCount
+	AssignmentCountStat      = openapi.AssignmentCountStat
+	AuthorStat               = openapi.AuthorStat
+	AssignmentEvent          = openapi.AssignmentEvent
+	AssignmentEventEventType = openapi.AssignmentEventEventType
+	AssignmentEventList      = openapi.AssignmentEventList
+	CodeHostAccount          = openapi.CodeHostAccount
+	CodeHostProvider         = openapi.CodeHostProvider
+	DeactivationResult       = openapi.DeactivationResult
+	FairnessStat             = openapi.FairnessStat
+	ImportMove               = openapi.ImportMove
+	ImportReport             = openapi.ImportReport
+	MemberLoad               = openapi.MemberLoad
+	NotificationContact      = openapi.NotificationContact
+	NotificationKind         = openapi.NotificationKind
+	PullRequest              = openapi.PullRequest
+	PullRequestDetails       = openapi.PullRequestDetails
+	PullRequestList          = openapi.PullRequestList
+	PullRequestShort         = openapi.PullRequestShort
+	PullRequestStat          = openapi.PullRequestStat
+	PullRequestStatus        = openapi.PullRequestStatus
+	Reassignment             = openapi.Reassignment
+	ReviewList               = openapi.ReviewList
+	ReviewerMergeTime        = openapi.ReviewerMergeTime
+	ReviewerSync             = openapi.ReviewerSync
+	ReviewerSyncStatus       = openapi.ReviewerSyncStatus
+	SortOrder                = openapi.SortOrder
+	Team                     = openapi.Team
+	TeamFairness             = openapi.TeamFairness
+	TeamList                 = openapi.TeamList
+	TeamMember               = openapi.TeamMember
+	TeamMergeTime            = openapi.TeamMergeTime
+	TeamMemberRole           = openapi.TeamMemberRole
+	TeamStat                 = openapi.TeamStat
+	TeamSummary              = openapi.TeamSummary
+	TimeToMergeStat          = openapi.TimeToMergeStat
+	User                     = openapi.User
+	UserDetails              = openapi.UserDetails
+	UserList                 = openapi.UserList
+	WebhookDelivery          = openapi.WebhookDelivery
+	WebhookDeliveryList      = openapi.WebhookDeliveryList
+	WebhookDeliveryStatus    = openapi.WebhookDeliveryStatus
+	WebhookEventType         = openapi.WebhookEventType
+	WebhookPayload           = openapi.WebhookPayload
+	WebhookSubscription      = openapi.WebhookSubscription
+)
+
+// Parameters of list calls.
+type (
+	ListTeamsParams             = openapi.GetTeamListParams
+	ImportParams                = openapi.PostImportParams
+	ListUsersParams             = openapi.GetUsersListParams
+	ListReviewsParams           = openapi.GetUsersGetReviewParams
+	UserHistoryParams           = openapi.GetUsersHistoryParams
+	ListPullRequestsParams      = openapi.GetPullRequestListParams
+	PullRequestHistoryParams    = openapi.GetPullRequestHistoryParams
+	ListWebhookDeliveriesParams = openapi.GetWebhooksDeliveriesParams
+	StatsParams                 = openapi.GetUsersStatsParams
+	TimeToMergeParams           = openapi.GetStatsTimeToMergeParams
+	FairnessParams              = openapi.GetStatsFairnessParams
+)
+
+// Request bodies.
+type (
+	CreatePullRequestRequest   = openapi.PostPullRequestCreateJSONRequestBody
+	ReassignPullRequestRequest = openapi.PostPullRequestReassignJSONRequestBody
+	SubscribeWebhookRequest    = openapi.PostWebhooksSubscribeJSONRequestBody
+)
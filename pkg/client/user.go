@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+func (c *Client) SetIsActive(ctx context.Context, userId string, isActive bool) (*User, error) {
+	rsp, err := c.api.PostUsersSetIsActiveWithResponse(idempotent(ctx), openapi.PostUsersSetIsActiveJSONRequestBody{
+		UserId:   userId,
+		IsActive: isActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil || rsp.JSON200.User == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200.User, nil
+}
+
+func (c *Client) GetUser(ctx context.Context, userId string) (*UserDetails, error) {
+	rsp, err := c.api.GetUsersGetWithResponse(ctx, &openapi.GetUsersGetParams{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.User, nil
+}
+
+func (c *Client) ListUsers(ctx context.Context, params ListUsersParams) (*UserList, error) {
+	rsp, err := c.api.GetUsersListWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+// ListReviews returns pull requests where the user is assigned as a reviewer.
+func (c *Client) ListReviews(ctx context.Context, params ListReviewsParams) (*ReviewList, error) {
+	rsp, err := c.api.GetUsersGetReviewWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+func (c *Client) GetUserHistory(ctx context.Context, params UserHistoryParams) (*AssignmentEventList, error) {
+	rsp, err := c.api.GetUsersHistoryWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetStats aggregates pull requests created or merged in the window
+// of params, all pull requests without bounds.
+func (c *Client) GetStats(ctx context.Context, params StatsParams) (*AssignmentCountStat, error) {
+	rsp, err := c.api.GetUsersStatsWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
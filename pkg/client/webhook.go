@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+
+	"avito-trainee-task/pkg/client/openapi"
+)
+
+func (c *Client) SubscribeWebhook(ctx context.Context, req SubscribeWebhookRequest) (*WebhookSubscription, error) {
+	rsp, err := c.api.PostWebhooksSubscribeWithResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON201 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON201.Subscription, nil
+}
+
+func (c *Client) UnsubscribeWebhook(ctx context.Context, subscriptionId int64) (*WebhookSubscription, error) {
+	rsp, err := c.api.PostWebhooksUnsubscribeWithResponse(ctx, openapi.PostWebhooksUnsubscribeJSONRequestBody{
+		SubscriptionId: subscriptionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Subscription, nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context) ([]WebhookSubscription, error) {
+	rsp, err := c.api.GetWebhooksListWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200.Subscriptions, nil
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) (*WebhookDeliveryList, error) {
+	rsp, err := c.api.GetWebhooksDeliveriesWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return rsp.JSON200, nil
+}
+
+// RedeliverWebhook queues the delivery again with the attempts reset.
+func (c *Client) RedeliverWebhook(ctx context.Context, deliveryId int64) (*WebhookDelivery, error) {
+	rsp, err := c.api.PostWebhooksRedeliverWithResponse(ctx, openapi.PostWebhooksRedeliverJSONRequestBody{
+		DeliveryId: deliveryId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.JSON200 == nil {
+		return nil, apiError(rsp.HTTPResponse, rsp.Body)
+	}
+	return &rsp.JSON200.Delivery, nil
+}